@@ -1,7 +1,11 @@
 package main
 
-import "github.com/keircn/gtl/internal/cli"
+import (
+	"os"
+
+	"github.com/keircn/gtl/internal/cli"
+)
 
 func main() {
-	cli.Run()
+	os.Exit(cli.Run())
 }
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/keircn/gtl/pkg/titlecase"
+)
+
+func runCheck(convert func(string) (string, error), input string, w io.Writer) (bool, error) {
+	result, err := convert(input)
+	if err != nil {
+		return false, err
+	}
+	if result == input {
+		return false, nil
+	}
+
+	fmt.Fprintf(w, "%q should be %q\n", input, result)
+	return true, nil
+}
+
+func checkLines(convert func(string) (string, error), reader *bufio.Reader, w io.Writer) (bool, error) {
+	found := false
+
+	for number := 1; ; number++ {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return found, fmt.Errorf("reading from stdin: %w", readErr)
+		}
+
+		text, _ := splitLineEnding(line)
+		if strings.TrimSpace(text) != "" {
+			result, err := convert(text)
+			if errors.Is(err, titlecase.ErrEmptyInput) {
+				result, err = text, nil
+			}
+			if err != nil {
+				return found, fmt.Errorf("line %d: %w", number, err)
+			}
+			if result != text {
+				fmt.Fprintf(w, "%d: %q should be %q\n", number, text, result)
+				found = true
+			}
+		}
+
+		if readErr == io.EOF {
+			return found, nil
+		}
+	}
+}
@@ -2,95 +2,375 @@ package cli
 
 import (
 	"bufio"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"strings"
 
-	"github.com/keircn/gtl/internal/titlecase"
+	"github.com/keircn/gtl/internal/config"
+	"github.com/keircn/gtl/internal/document"
+	"github.com/keircn/gtl/internal/lsp"
+	"github.com/keircn/gtl/pkg/titlecase"
 	"github.com/keircn/gtl/pkg/version"
 )
 
-func Run() {
-	flag.Usage = func() {
-		showHelp()
-		os.Exit(1)
+const (
+	exitOK         = 0
+	exitViolations = 1
+	exitError      = 2
+)
+
+func Run() int {
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "lsp" {
+		if err := lsp.Serve(os.Stdin, os.Stdout); err != nil {
+			return fail(err)
+		}
+		return exitOK
+	}
+
+	command := ""
+	if len(args) > 0 && args[0] == "lint" {
+		command, args = args[0], args[1:]
 	}
 
+	flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+	flag.Usage = showHelp
+
 	var (
 		helpFlag     = flag.Bool("help", false, "Show help information")
 		helpFlagH    = flag.Bool("h", false, "Show help information")
 		versionFlag  = flag.Bool("version", false, "Show version information")
 		versionFlagV = flag.Bool("v", false, "Show version information")
+		styleFlag    = flag.String("style", "chicago", "Capitalization style")
+		sentenceFlag = flag.Bool("sentence", false, "Convert to sentence case")
+		markdownFlag = flag.Bool("markdown", false, "Treat input as Markdown")
+		writeFlag    = flag.Bool("write", false, "Rewrite files in place")
+		backupFlag   = flag.String("backup", "", "Keep originals of rewritten files with this suffix")
+		diffFlag     = flag.Bool("diff", false, "Print a unified diff instead of rewriting")
+		checkFlag    = flag.Bool("check", false, "Only list titles that need changes")
+		linesFlag    = flag.Bool("lines", false, "Convert each line of stdin separately")
+		configFlag   = flag.String("config", "", "Path to the configuration file")
+		mixedFlag    = flag.Bool("mixed-case", true, "Keep words with internal capitals")
+		escapeFlag   = flag.String("escape", "", "Marker that leaves wrapped text unchanged")
+		explainFlag  = flag.Bool("explain", false, "Show the rule applied to each word")
+		formatFlag   = flag.String("format", "text", "Output format: text or json")
+		maxLenFlag   = flag.Int("max-length", 0, "Longest title in bytes; negative for no limit")
+		includeFlag  listFlag
+		excludeFlag  listFlag
 	)
+	flag.Var(&includeFlag, "include", "Only process files matching the pattern")
+	flag.Var(&excludeFlag, "exclude", "Skip files and directories matching the pattern")
 
-	flag.Parse()
+	args, err := parseArgs(args)
+	if err != nil {
+		return exitError
+	}
 
 	if *helpFlag || *helpFlagH {
 		showHelp()
-		return
+		return exitOK
 	}
 
 	if *versionFlag || *versionFlagV {
 		showVersion()
-		return
+		return exitOK
+	}
+
+	if *formatFlag != "text" && *formatFlag != "json" {
+		return fail(fmt.Errorf("unknown format %q (use text or json)", *formatFlag))
+	}
+
+	cfg, err := loadConfig(*configFlag)
+	if err != nil {
+		return fail(err)
+	}
+	if isFlagSet("style") {
+		cfg.Style = *styleFlag
+	}
+	if isFlagSet("mixed-case") {
+		cfg.MixedCase = mixedFlag
+	}
+	if isFlagSet("escape") {
+		cfg.Escape = *escapeFlag
+	}
+	if isFlagSet("max-length") {
+		cfg.MaxLength = *maxLenFlag
+	}
+
+	opts, err := cfg.Options()
+	if err != nil {
+		return fail(err)
+	}
+	titler := titlecase.New(opts)
+
+	convert := titler.Title
+	if *sentenceFlag {
+		convert = titler.Sentence
+	}
+
+	pathMode, err := isPathArgs(args)
+	if err != nil {
+		return fail(err)
+	}
+	fileMode := *markdownFlag || pathMode
+	if *explainFlag && (*sentenceFlag || fileMode || command == "lint") {
+		return fail(errors.New("--explain cannot be used with --sentence, files or lint"))
+	}
+	if *sentenceFlag && command == "lint" && !fileMode {
+		return fail(errors.New("--sentence can only be used with lint on files"))
+	}
+	if *backupFlag != "" && !*writeFlag {
+		return fail(errors.New("--backup requires --write"))
+	}
+	if *diffFlag && (*writeFlag || command == "lint" || *formatFlag == "json") {
+		return fail(errors.New("--diff cannot be used with --write, lint or --format json"))
+	}
+	if *checkFlag && (*writeFlag || *diffFlag || *explainFlag || command == "lint") {
+		return fail(errors.New("--check cannot be used with --write, --diff, --explain or lint"))
+	}
+
+	var report *jsonReport
+	if *formatFlag == "json" {
+		report = newJSONReport(os.Stdout, titler, *sentenceFlag, command == "lint", *checkFlag)
+	}
+
+	if fileMode {
+		opts := fileOptions{
+			include: includeFlag,
+			exclude: excludeFlag,
+			lint:    command == "lint",
+			write:   *writeFlag,
+			backup:  *backupFlag,
+			diff:    *diffFlag,
+			check:   *checkFlag,
+		}
+		if *markdownFlag {
+			opts.format = document.MarkdownFormat
+		}
+		found, err := runFiles(convert, args, opts, report)
+		if err != nil {
+			return fail(err)
+		}
+		if report != nil && report.failed {
+			return exitError
+		}
+		return status(found)
 	}
 
-	var input string
+	if *writeFlag || *diffFlag {
+		return fail(errors.New("--write and --diff require file arguments"))
+	}
 
-	if flag.NArg() > 0 {
-		input = strings.Join(flag.Args(), " ")
+	var lines []string
+
+	if len(args) > 0 {
+		lines = []string{strings.Join(args, " ")}
 	} else {
 		stat, _ := os.Stdin.Stat()
 		if (stat.Mode() & os.ModeCharDevice) != 0 {
 			showHelp()
-			return
+			return exitOK
 		}
 
-		scanner := bufio.NewScanner(os.Stdin)
-		var lines []string
-		for scanner.Scan() {
-			lines = append(lines, scanner.Text())
+		reader := bufio.NewReader(os.Stdin)
+
+		if command != "lint" && !*explainFlag {
+			perLine := *linesFlag
+			if !isFlagSet("lines") {
+				reader, perLine = hasMultipleLines(reader)
+			}
+
+			if perLine && report != nil {
+				if err := report.stream(reader); err != nil {
+					return fail(err)
+				}
+				return report.status()
+			}
+
+			if perLine && *checkFlag {
+				found, err := checkLines(convert, reader, os.Stdout)
+				if err != nil {
+					return fail(err)
+				}
+				return status(found)
+			}
+
+			if perLine {
+				if err := runLines(titler, convert, reader, os.Stdout); err != nil {
+					return fail(err)
+				}
+				return exitOK
+			}
 		}
-		if err := scanner.Err(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading from stdin: %v\n", err)
-			os.Exit(1)
+
+		lines, err = readLines(reader)
+		if err != nil {
+			return fail(fmt.Errorf("reading from stdin: %w", err))
 		}
-		input = strings.Join(lines, " ")
 	}
 
+	input := strings.Join(lines, " ")
 	if strings.TrimSpace(input) == "" {
 		showHelp()
-		return
+		return exitOK
+	}
+
+	if report != nil {
+		var err error
+		if command == "lint" && len(args) == 0 {
+			err = report.lines(lines)
+		} else {
+			err = report.text(0, input)
+		}
+		if err != nil {
+			return fail(err)
+		}
+		return report.status()
 	}
 
-	result, err := titlecase.ToTitleCase(input)
+	if command == "lint" {
+		found, err := runLint(titler, lines)
+		if err != nil {
+			return fail(err)
+		}
+		return status(found)
+	}
+
+	if *checkFlag {
+		found, err := runCheck(convert, input, os.Stdout)
+		if err != nil {
+			return fail(err)
+		}
+		return status(found)
+	}
+
+	if *explainFlag {
+		if err := runExplain(titler, input); err != nil {
+			return fail(err)
+		}
+		return exitOK
+	}
+
+	result, err := convert(input)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		return fail(err)
 	}
 	fmt.Println(result)
+	return exitOK
+}
+
+func fail(err error) int {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	return exitError
+}
+
+func status(found bool) int {
+	if found {
+		return exitViolations
+	}
+	return exitOK
+}
+
+func parseArgs(args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flag.CommandLine.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := flag.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+func loadConfig(path string) (*config.Config, error) {
+	if path == "" {
+		found, err := config.Find(".")
+		if err != nil {
+			return nil, err
+		}
+		if found == "" {
+			return &config.Config{}, nil
+		}
+		path = found
+	}
+
+	return config.Load(path)
+}
+
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
 }
 
 func showHelp() {
 	fmt.Println("gtl - Go Title Linter")
-	fmt.Println("Transforms text into properly capitalized titles according to the Chicago Manual of Style.")
+	fmt.Println("Transforms text into properly capitalized titles according to a style guide.")
 	fmt.Println()
 	showUsage()
 	fmt.Println()
 	fmt.Println("Options:")
-	fmt.Println("  -h, --help     Show this help message")
-	fmt.Println("  -v, --version  Show version information")
+	fmt.Println("  -h, --help         Show this help message")
+	fmt.Println("  -v, --version      Show version information")
+	fmt.Println("      --style NAME   Capitalization style: chicago (default), ap, apa, mla")
+	fmt.Println("      --sentence     Convert to sentence case instead of title case")
+	fmt.Println("      --markdown     Treat stdin and every file as Markdown")
+	fmt.Println("      --write        Rewrite files in place")
+	fmt.Println("      --backup SUF   With --write, keep each original as file+SUF, e.g. .orig")
+	fmt.Println("      --diff         Print a unified diff of the changes without writing")
+	fmt.Println("      --check        Only list titles that need changes; exit 0 if none,")
+	fmt.Println("                     1 if some do and 2 on errors")
+	fmt.Println("      --include PAT  Only process files matching PAT (repeatable)")
+	fmt.Println("      --exclude PAT  Skip files and directories matching PAT (repeatable)")
+	fmt.Println("      --lines        Convert each line of stdin separately (default for")
+	fmt.Println("                     multi-line input; --lines=false joins lines instead)")
+	fmt.Println("      --config PATH  Configuration file (default: nearest .gtl.json)")
+	fmt.Println("      --mixed-case   Keep words with internal capitals such as useEffect")
+	fmt.Println("                     (default true; --mixed-case=false lowercases them)")
+	fmt.Println("      --escape MARK  Leave text wrapped in MARK unchanged, e.g. =iphone=")
+	fmt.Println("      --explain      Show the rule that decided the casing of each word")
+	fmt.Println("      --format FMT   Output format: text (default) or json; multiple lines")
+	fmt.Println("                     and files give one JSON object per line")
+	fmt.Println("      --max-length N Longest title in bytes (default 10000; negative for")
+	fmt.Println("                     no limit)")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  gtl \"the quick brown fox\"")
 	fmt.Println("  echo \"the quick brown fox\" | gtl")
+	fmt.Println("  gtl < headings.txt")
+	fmt.Println("  gtl --style ap \"a guide to life with cats\"")
+	fmt.Println("  gtl --sentence \"Getting Started With The API\"")
+	fmt.Println("  gtl --escape = \"why =iphone= is spelled that way\"")
+	fmt.Println("  gtl --explain \"set up your self-hosted server\"")
+	fmt.Println("  gtl --format json \"the quick brown fox\"")
+	fmt.Println("  gtl lint \"The Lord Of The Rings\"")
+	fmt.Println("  gtl --check docs")
+	fmt.Println("  gtl lint README.md")
+	fmt.Println("  gtl lint docs --exclude drafts")
+	fmt.Println("  gtl --diff docs")
+	fmt.Println("  gtl --write --backup=.orig 'docs/**/*.md'")
 }
 
 func showUsage() {
 	fmt.Println("Usage:")
 	fmt.Println("  gtl [options] [text]")
 	fmt.Println("  echo \"text\" | gtl [options]")
+	fmt.Println("  gtl lint [options] [text]")
+	fmt.Println("  gtl [options] path...")
+	fmt.Println("  gtl lsp")
 }
 
 func showVersion() {
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/keircn/gtl/pkg/titlecase"
+)
+
+func runExplain(titler *titlecase.Titler, input string) error {
+	decisions, err := titler.Explain(input)
+	if err != nil {
+		return err
+	}
+
+	var result strings.Builder
+	for _, d := range decisions {
+		result.WriteString(d.Output)
+	}
+	fmt.Println(result.String())
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TOKEN\tRESULT\tRULE")
+	for _, d := range decisions {
+		if strings.TrimSpace(d.Token.Text) == "" {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", d.Token.Text, d.Output, d.Rule)
+		for _, part := range d.Parts {
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", part.Token.Text, part.Output, part.Rule)
+		}
+	}
+
+	return w.Flush()
+}
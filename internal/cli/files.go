@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/keircn/gtl/internal/diff"
+	"github.com/keircn/gtl/internal/document"
+	"github.com/keircn/gtl/internal/files"
+)
+
+type fileOptions struct {
+	format  *document.Format
+	include []string
+	exclude []string
+	lint    bool
+	write   bool
+	backup  string
+	diff    bool
+	check   bool
+}
+
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func isPathArgs(args []string) (bool, error) {
+	missing := ""
+	paths := 0
+	for _, arg := range args {
+		switch {
+		case files.IsPath(arg):
+			paths++
+		case looksLikePath(arg):
+			if missing == "" {
+				missing = arg
+			}
+			paths++
+		}
+	}
+
+	// Text such as "how to edit README.md" mentions a file without naming
+	// one, so a missing path is only an error when nothing else is given.
+	if paths == 0 || paths < len(args) {
+		return false, nil
+	}
+	if missing != "" {
+		return false, fmt.Errorf("%s: no such file, directory or matching glob", missing)
+	}
+	return true, nil
+}
+
+func looksLikePath(arg string) bool {
+	if strings.ContainsFunc(arg, unicode.IsSpace) {
+		return false
+	}
+	if document.FormatFor(arg) != nil || strings.Contains(arg, "*") {
+		return true
+	}
+
+	dir, _ := filepath.Split(arg)
+	if dir == "" {
+		return false
+	}
+	if strings.ContainsAny(arg, "?[") {
+		return true
+	}
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
+
+func runFiles(convert func(string) (string, error), paths []string, opts fileOptions, report *jsonReport) (bool, error) {
+	if len(paths) == 0 {
+		if opts.write {
+			return false, errors.New("--write requires file arguments")
+		}
+
+		src, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return false, fmt.Errorf("reading from stdin: %w", err)
+		}
+		_, changes, err := processDocument(convert, "<stdin>", src, opts.format, opts, report == nil, report)
+		return (opts.lint || opts.check) && changes > 0, err
+	}
+
+	expanded, err := files.Expand(paths, files.Options{
+		Include: opts.include,
+		Exclude: opts.exclude,
+		Known: func(path string) bool {
+			format := document.WalkFormatFor(path)
+			return format != nil && (opts.format == nil || format == opts.format)
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(expanded) == 0 {
+		return false, errors.New("no matching files found")
+	}
+
+	summary := report == nil && !opts.diff && !opts.check && (opts.lint || opts.write || len(expanded) > 1 || filepath.Clean(paths[0]) != filepath.Clean(expanded[0]))
+	verb := "would change"
+	switch {
+	case opts.lint:
+		verb = "need changes"
+	case opts.write:
+		verb = "rewritten"
+	}
+
+	totalTitles, totalChanges := 0, 0
+	for _, path := range expanded {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return false, err
+		}
+
+		format := opts.format
+		if format == nil {
+			format = document.FormatFor(path)
+		}
+		if format == nil {
+			format = document.TextFormat
+		}
+
+		titles, changes, err := processDocument(convert, path, src, format, opts, report == nil && !summary, report)
+		if err != nil {
+			return false, err
+		}
+		if summary {
+			fmt.Printf("%s: %d of %d title(s) %s\n", path, changes, titles, verb)
+		}
+		totalTitles += titles
+		totalChanges += changes
+	}
+
+	if summary && len(expanded) > 1 {
+		fmt.Printf("%d file(s): %d of %d title(s) %s\n", len(expanded), totalChanges, totalTitles, verb)
+	}
+
+	return (opts.lint || opts.check) && totalChanges > 0, nil
+}
+
+func processDocument(convert func(string) (string, error), name string, src []byte, format *document.Format, opts fileOptions, print bool, report *jsonReport) (int, int, error) {
+	changes, err := format.Changes(src, convert)
+	if err != nil {
+		if report != nil {
+			return 0, 0, report.fileError(name, err)
+		}
+		return 0, 0, fmt.Errorf("%s: %w", name, err)
+	}
+
+	headings := format.Headings(src)
+	if report != nil {
+		if err := report.headings(name, headings, changes); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	switch {
+	case opts.lint || opts.check:
+		if report == nil {
+			for _, change := range changes {
+				fmt.Printf("%s:%d: %q should be %q\n", name, change.Line, change.Text, change.Expected)
+			}
+		}
+	case opts.write:
+		if len(changes) == 0 {
+			break
+		}
+		if err := files.WriteFile(name, document.Apply(src, changes), opts.backup); err != nil {
+			return 0, 0, err
+		}
+	case opts.diff:
+		os.Stdout.Write(diff.Unified(filepath.ToSlash(name), src, document.Apply(src, changes)))
+	case print:
+		os.Stdout.Write(document.Apply(src, changes))
+	}
+
+	return len(headings), len(changes), nil
+}
@@ -0,0 +1,236 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/keircn/gtl/internal/document"
+	"github.com/keircn/gtl/pkg/titlecase"
+)
+
+type result struct {
+	File       string        `json:"file,omitempty"`
+	Line       int           `json:"line,omitempty"`
+	Input      string        `json:"input"`
+	Output     string        `json:"output"`
+	Changed    bool          `json:"changed"`
+	Style      string        `json:"style"`
+	Tokens     []tokenResult `json:"tokens,omitempty"`
+	Violations []violation   `json:"violations,omitempty"`
+	Error      *errorResult  `json:"error,omitempty"`
+}
+
+type tokenResult struct {
+	Text   string        `json:"text"`
+	Output string        `json:"output"`
+	Rule   string        `json:"rule"`
+	Parts  []tokenResult `json:"parts,omitempty"`
+}
+
+type violation struct {
+	Word     string `json:"word"`
+	Expected string `json:"expected"`
+	Offset   int    `json:"offset"`
+	Column   int    `json:"column"`
+}
+
+type errorResult struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type jsonReport struct {
+	encoder  *json.Encoder
+	titler   *titlecase.Titler
+	sentence bool
+	lint     bool
+	check    bool
+	failed   bool
+	found    bool
+}
+
+func newJSONReport(w io.Writer, titler *titlecase.Titler, sentence, lint, check bool) *jsonReport {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &jsonReport{encoder: encoder, titler: titler, sentence: sentence, lint: lint, check: check}
+}
+
+func (r *jsonReport) status() int {
+	switch {
+	case r.failed:
+		return exitError
+	case r.found:
+		return exitViolations
+	default:
+		return exitOK
+	}
+}
+
+func (r *jsonReport) text(line int, input string) error {
+	res := result{Line: line, Input: input, Style: r.titler.Style().Name()}
+
+	var err error
+	if r.sentence {
+		res.Output, err = r.titler.Sentence(input)
+	} else {
+		var decisions []titlecase.Decision
+		decisions, err = r.titler.Explain(input)
+		var output strings.Builder
+		for _, d := range decisions {
+			output.WriteString(d.Output)
+		}
+		res.Output = output.String()
+		res.Tokens = tokenResults(decisions)
+	}
+
+	if err == nil && r.lint {
+		var violations []titlecase.Violation
+		violations, err = r.titler.Lint(input)
+		for _, v := range violations {
+			res.Violations = append(res.Violations, violation{Word: v.Word, Expected: v.Expected, Offset: v.Offset, Column: v.Column})
+		}
+	}
+
+	// A line of a multi-line input without words, such as a "---"
+	// separator, is passed through unchanged like runLines does.
+	if line > 0 && errors.Is(err, titlecase.ErrEmptyInput) {
+		res.Output, res.Tokens, res.Violations, err = input, nil, nil, nil
+	}
+	if err != nil {
+		res.Output = ""
+		res.Tokens = nil
+		res.Error = newErrorResult(err)
+	}
+	res.Changed = err == nil && res.Output != input
+	if err != nil {
+		r.failed = true
+	}
+	if len(res.Violations) > 0 || r.check && res.Changed {
+		r.found = true
+	}
+	if r.check && err == nil && !res.Changed {
+		return nil
+	}
+
+	return r.encoder.Encode(res)
+}
+
+func (r *jsonReport) lines(lines []string) error {
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if err := r.text(i+1, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *jsonReport) stream(reader *bufio.Reader) error {
+	for number := 1; ; number++ {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return fmt.Errorf("reading from stdin: %w", readErr)
+		}
+
+		text, _ := splitLineEnding(line)
+		if strings.TrimSpace(text) != "" {
+			if err := r.text(number, text); err != nil {
+				return err
+			}
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
+
+func (r *jsonReport) headings(file string, headings []document.Heading, changes []document.Change) error {
+	expected := make(map[int]string, len(changes))
+	for _, change := range changes {
+		expected[change.Line] = change.Expected
+	}
+
+	for _, heading := range headings {
+		output, changed := expected[heading.Line]
+		if !changed {
+			if r.check {
+				continue
+			}
+			output = heading.Text
+		}
+		if changed && (r.lint || r.check) {
+			r.found = true
+		}
+		res := result{
+			File:    file,
+			Line:    heading.Line,
+			Input:   heading.Text,
+			Output:  output,
+			Changed: changed,
+			Style:   r.titler.Style().Name(),
+		}
+		if !r.sentence {
+			if decisions, err := r.titler.Explain(heading.Text); err == nil {
+				res.Tokens = tokenResults(decisions)
+			}
+		}
+		if err := r.encoder.Encode(res); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *jsonReport) fileError(file string, err error) error {
+	res := result{File: file, Style: r.titler.Style().Name(), Error: newErrorResult(err)}
+
+	var headingErr *document.HeadingError
+	if errors.As(err, &headingErr) {
+		res.Line = headingErr.Line
+		res.Error.Message = headingErr.Err.Error()
+	}
+
+	r.failed = true
+	return r.encoder.Encode(res)
+}
+
+func tokenResults(decisions []titlecase.Decision) []tokenResult {
+	var tokens []tokenResult
+	for _, d := range decisions {
+		if strings.TrimSpace(d.Token.Text) == "" {
+			continue
+		}
+		tokens = append(tokens, tokenResult{
+			Text:   d.Token.Text,
+			Output: d.Output,
+			Rule:   d.Rule.String(),
+			Parts:  tokenResults(d.Parts),
+		})
+	}
+	return tokens
+}
+
+func newErrorResult(err error) *errorResult {
+	return &errorResult{Code: errorCode(err), Message: err.Error()}
+}
+
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, titlecase.ErrInputTooLong):
+		return "input_too_long"
+	case errors.Is(err, titlecase.ErrInvalidUnicode):
+		return "invalid_unicode"
+	case errors.Is(err, titlecase.ErrEmptyInput):
+		return "empty_input"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/keircn/gtl/internal/document"
+	"github.com/keircn/gtl/pkg/titlecase"
+)
+
+func TestJSONReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     titlecase.Options
+		sentence bool
+		lint     bool
+		check    bool
+		run      func(r *jsonReport) error
+		expected string
+		status   int
+	}{
+		{
+			name: "title with tokens",
+			run:  func(r *jsonReport) error { return r.text(0, "the fox") },
+			expected: `{"input":"the fox","output":"The Fox","changed":true,"style":"chicago","tokens":[{"text":"the","output":"The","rule":"first-word"},{"text":"fox","output":"Fox","rule":"capitalized"}]}
+`,
+			status: exitOK,
+		},
+		{
+			name:     "sentence omits tokens",
+			sentence: true,
+			run:      func(r *jsonReport) error { return r.text(0, "The Fox") },
+			expected: `{"input":"The Fox","output":"The fox","changed":true,"style":"chicago"}
+`,
+			status: exitOK,
+		},
+		{
+			name:     "stream numbers lines and passes separators through",
+			sentence: true,
+			run: func(r *jsonReport) error {
+				return r.stream(bufio.NewReader(strings.NewReader("the fox\n---\n\nthe hound\r\n")))
+			},
+			expected: `{"line":1,"input":"the fox","output":"The fox","changed":true,"style":"chicago"}
+{"line":2,"input":"---","output":"---","changed":false,"style":"chicago"}
+{"line":4,"input":"the hound","output":"The hound","changed":true,"style":"chicago"}
+`,
+			status: exitOK,
+		},
+		{
+			name: "lint reports violations",
+			lint: true,
+			run:  func(r *jsonReport) error { return r.lines([]string{"a Tale", "", "The End"}) },
+			expected: `{"line":1,"input":"a Tale","output":"A Tale","changed":true,"style":"chicago","tokens":[{"text":"a","output":"A","rule":"first-word"},{"text":"Tale","output":"Tale","rule":"capitalized"}],"violations":[{"word":"a","expected":"A","offset":0,"column":1}]}
+{"line":3,"input":"The End","output":"The End","changed":false,"style":"chicago","tokens":[{"text":"The","output":"The","rule":"first-word"},{"text":"End","output":"End","rule":"capitalized"}]}
+`,
+			status: exitViolations,
+		},
+		{
+			name: "lint without violations",
+			lint: true,
+			run:  func(r *jsonReport) error { return r.lines([]string{"The End"}) },
+			expected: `{"line":1,"input":"The End","output":"The End","changed":false,"style":"chicago","tokens":[{"text":"The","output":"The","rule":"first-word"},{"text":"End","output":"End","rule":"capitalized"}]}
+`,
+			status: exitOK,
+		},
+		{
+			name:     "check lists only changes",
+			sentence: true,
+			check:    true,
+			run: func(r *jsonReport) error {
+				return r.stream(bufio.NewReader(strings.NewReader("The fox\nthe hound\n")))
+			},
+			expected: `{"line":2,"input":"the hound","output":"The hound","changed":true,"style":"chicago"}
+`,
+			status: exitViolations,
+		},
+		{
+			name:     "check without changes",
+			sentence: true,
+			check:    true,
+			run:      func(r *jsonReport) error { return r.text(0, "The fox") },
+			expected: "",
+			status:   exitOK,
+		},
+		{
+			name:     "error",
+			opts:     titlecase.Options{MaxLength: 5},
+			sentence: true,
+			run:      func(r *jsonReport) error { return r.text(0, "the quick fox") },
+			expected: `{"input":"the quick fox","output":"","changed":false,"style":"chicago","error":{"code":"input_too_long","message":"input text exceeds maximum length: 13 bytes, limit is 5"}}
+`,
+			status: exitError,
+		},
+		{
+			name: "lint headings counts only changes",
+			lint: true,
+			run:  markdownHeadings("# The End\n\n## the fox\n"),
+			expected: `{"file":"a.md","line":1,"input":"The End","output":"The End","changed":false,"style":"chicago","tokens":[{"text":"The","output":"The","rule":"first-word"},{"text":"End","output":"End","rule":"capitalized"}]}
+{"file":"a.md","line":3,"input":"the fox","output":"The Fox","changed":true,"style":"chicago","tokens":[{"text":"the","output":"The","rule":"first-word"},{"text":"fox","output":"Fox","rule":"capitalized"}]}
+`,
+			status: exitViolations,
+		},
+		{
+			name:     "lint clean headings in sentence case",
+			sentence: true,
+			lint:     true,
+			run:      markdownHeadings("# The end\n"),
+			expected: `{"file":"a.md","line":1,"input":"The end","output":"The end","changed":false,"style":"chicago"}` + "\n",
+			status:   exitOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			r := newJSONReport(&buf, titlecase.New(tt.opts), tt.sentence, tt.lint, tt.check)
+			if err := tt.run(r); err != nil {
+				t.Fatalf("run returned unexpected error: %v", err)
+			}
+			if result := buf.String(); result != tt.expected {
+				t.Errorf("output = %s, want %s", result, tt.expected)
+			}
+			if status := r.status(); status != tt.status {
+				t.Errorf("status() = %d, want %d", status, tt.status)
+			}
+		})
+	}
+}
+
+func markdownHeadings(src string) func(r *jsonReport) error {
+	return func(r *jsonReport) error {
+		format := document.MarkdownFormat
+		convert := r.titler.Title
+		if r.sentence {
+			convert = r.titler.Sentence
+		}
+		changes, err := format.Changes([]byte(src), convert)
+		if err != nil {
+			return err
+		}
+		return r.headings("a.md", format.Headings([]byte(src)), changes)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		found    bool
+		expected int
+	}{
+		{false, exitOK},
+		{true, exitViolations},
+	}
+
+	for _, tt := range tests {
+		if result := status(tt.found); result != tt.expected {
+			t.Errorf("status(%t) = %d, want %d", tt.found, result, tt.expected)
+		}
+	}
+}
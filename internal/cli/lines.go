@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/keircn/gtl/pkg/titlecase"
+)
+
+func hasMultipleLines(reader *bufio.Reader) (*bufio.Reader, bool) {
+	first, err := reader.ReadString('\n')
+	if err != nil {
+		return bufio.NewReader(strings.NewReader(first)), false
+	}
+
+	_, err = reader.Peek(1)
+	multiple := err == nil
+
+	return bufio.NewReader(io.MultiReader(strings.NewReader(first), reader)), multiple
+}
+
+func runLines(titler *titlecase.Titler, convert func(string) (string, error), reader io.Reader, w io.Writer) error {
+	err := titler.Stream(context.Background(), reader, w, titlecase.StreamOptions{Transform: convert})
+
+	var recordErr *titlecase.RecordError
+	if errors.As(err, &recordErr) {
+		return fmt.Errorf("line %d: %w", recordErr.Record, recordErr.Err)
+	}
+	return err
+}
+
+func readLines(reader io.Reader) ([]string, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimSuffix(string(data), "\n")
+	if text == "" {
+		return nil, nil
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines, nil
+}
+
+func splitLineEnding(line string) (string, string) {
+	if strings.HasSuffix(line, "\r\n") {
+		return line[:len(line)-2], "\r\n"
+	}
+	if strings.HasSuffix(line, "\n") {
+		return line[:len(line)-1], "\n"
+	}
+	return line, ""
+}
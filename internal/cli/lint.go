@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/keircn/gtl/pkg/titlecase"
+)
+
+func runLint(titler *titlecase.Titler, lines []string) (bool, error) {
+	found := false
+
+	for i, line := range lines {
+		violations, err := titler.Lint(line)
+		if errors.Is(err, titlecase.ErrEmptyInput) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		for _, v := range violations {
+			fmt.Printf("%d:%d: %q should be %q (byte %d)\n", i+1, v.Column, v.Word, v.Expected, v.Offset)
+			found = true
+		}
+	}
+
+	return found, nil
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/keircn/gtl/pkg/titlecase"
+)
+
+const FileName = ".gtl.json"
+
+type Config struct {
+	Style        string   `json:"style"`
+	SmallWords   []string `json:"smallWords"`
+	Words        []string `json:"words"`
+	Dictionaries []string `json:"dictionaries"`
+	MixedCase    *bool    `json:"mixedCase"`
+	Acronyms     []string `json:"acronyms"`
+	Escape       string   `json:"escape"`
+	MaxLength    int      `json:"maxLength"`
+
+	dir string
+}
+
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		path := filepath.Join(dir, FileName)
+		info, err := os.Stat(path)
+		if err == nil && !info.IsDir() {
+			return path, nil
+		}
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	cfg.dir = filepath.Dir(path)
+
+	return &cfg, nil
+}
+
+func (c *Config) Options() (titlecase.Options, error) {
+	opts := titlecase.Options{
+		SmallWords: c.SmallWords,
+		Words:      c.Words,
+		Acronyms:   c.Acronyms,
+		Escape:     c.Escape,
+		MaxLength:  c.MaxLength,
+	}
+
+	if c.MixedCase != nil {
+		opts.DisableMixedCase = !*c.MixedCase
+	}
+
+	for _, path := range c.Dictionaries {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(c.dir, path)
+		}
+		words, err := readWordList(path)
+		if err != nil {
+			return titlecase.Options{}, err
+		}
+		opts.Words = append(opts.Words, words...)
+	}
+
+	if c.Style != "" {
+		style, err := titlecase.LookupStyle(c.Style)
+		if err != nil {
+			return titlecase.Options{}, err
+		}
+		opts.Style = style
+	}
+
+	return opts, nil
+}
+
+func readWordList(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var words []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+
+	return words, nil
+}
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/keircn/gtl/pkg/titlecase"
+)
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	docs := filepath.Join(root, "docs")
+	guides := filepath.Join(docs, "guides")
+	src := filepath.Join(root, "src")
+	for _, dir := range []string{guides, src} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rootConfig := filepath.Join(root, FileName)
+	docsConfig := filepath.Join(docs, FileName)
+	for _, path := range []string{rootConfig, docsConfig} {
+		if err := os.WriteFile(path, []byte(`{}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		dir      string
+		expected string
+	}{
+		{dir: root, expected: rootConfig},
+		{dir: src, expected: rootConfig},
+		{dir: docs, expected: docsConfig},
+		{dir: guides, expected: docsConfig},
+	}
+
+	for _, tt := range tests {
+		path, err := Find(tt.dir)
+		if err != nil {
+			t.Errorf("Find(%q) returned unexpected error: %v", tt.dir, err)
+			continue
+		}
+		if path != tt.expected {
+			t.Errorf("Find(%q) = %q, want %q", tt.dir, path, tt.expected)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected *Config
+		wantErr  bool
+	}{
+		{
+			name:    "all fields",
+			content: `{"style": "ap", "smallWords": ["from"], "words": ["GitHub", "macOS"], "acronyms": ["GTL"], "escape": "=", "maxLength": 500}`,
+			expected: &Config{
+				Style:      "ap",
+				SmallWords: []string{"from"},
+				Words:      []string{"GitHub", "macOS"},
+				Acronyms:   []string{"GTL"},
+				Escape:     "=",
+				MaxLength:  500,
+			},
+		},
+		{
+			name:     "empty object",
+			content:  `{}`,
+			expected: &Config{},
+		},
+		{
+			name:    "unknown field",
+			content: `{"stlye": "ap"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			content: `{"style": `,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), FileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := Load(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Load(%q) = %+v, want error", tt.content, cfg)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Load(%q) returned unexpected error: %v", tt.content, err)
+				return
+			}
+			tt.expected.dir = filepath.Dir(path)
+			if !reflect.DeepEqual(cfg, tt.expected) {
+				t.Errorf("Load(%q) = %+v, want %+v", tt.content, cfg, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOptionsDictionaries(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "words.txt"), []byte("# product names\nAcmeCloud\n\n  AcmeDB  \n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(dir, FileName)
+	if err := os.WriteFile(configPath, []byte(`{"words": ["GitHub"], "dictionaries": ["words.txt"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load(%q) returned unexpected error: %v", configPath, err)
+	}
+	opts, err := cfg.Options()
+	if err != nil {
+		t.Fatalf("Options() returned unexpected error: %v", err)
+	}
+
+	expected := []string{"GitHub", "AcmeCloud", "AcmeDB"}
+	if !reflect.DeepEqual(opts.Words, expected) {
+		t.Errorf("Options().Words = %q, want %q", opts.Words, expected)
+	}
+
+	cfg.Dictionaries = []string{"missing.txt"}
+	if _, err := cfg.Options(); err == nil {
+		t.Errorf("Options() with missing dictionary returned no error")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	cfg := &Config{Style: "mla", Words: []string{"GitHub"}}
+	opts, err := cfg.Options()
+	if err != nil {
+		t.Fatalf("Options() returned unexpected error: %v", err)
+	}
+	if opts.Style != titlecase.MLA {
+		t.Errorf("Options().Style = %v, want %v", opts.Style, titlecase.MLA)
+	}
+
+	mixedCase := false
+	cfg = &Config{MixedCase: &mixedCase}
+	opts, err = cfg.Options()
+	if err != nil {
+		t.Fatalf("Options() returned unexpected error: %v", err)
+	}
+	if !opts.DisableMixedCase {
+		t.Errorf("Options().DisableMixedCase = false, want true")
+	}
+
+	cfg = &Config{Style: "harvard"}
+	if _, err := cfg.Options(); err == nil {
+		t.Errorf("Options() with style %q returned no error", cfg.Style)
+	}
+}
@@ -0,0 +1,73 @@
+package diff
+
+import (
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected string
+	}{
+		{
+			name:     "identical",
+			a:        "# The End\n",
+			b:        "# The End\n",
+			expected: "",
+		},
+		{
+			name: "single line",
+			a:    "# the end\n\nbody\n",
+			b:    "# The End\n\nbody\n",
+			expected: "--- a/doc.md\n+++ b/doc.md\n" +
+				"@@ -1,3 +1,3 @@\n-# the end\n+# The End\n \n body\n",
+		},
+		{
+			name: "separate hunks",
+			a:    "# one\n1\n2\n3\n4\n5\n6\n7\n8\n# two\n",
+			b:    "# One\n1\n2\n3\n4\n5\n6\n7\n8\n# Two\n",
+			expected: "--- a/doc.md\n+++ b/doc.md\n" +
+				"@@ -1,4 +1,4 @@\n-# one\n+# One\n 1\n 2\n 3\n" +
+				"@@ -7,4 +7,4 @@\n 6\n 7\n 8\n-# two\n+# Two\n",
+		},
+		{
+			name: "merged hunks",
+			a:    "# one\n1\n2\n3\n# two\n",
+			b:    "# One\n1\n2\n3\n# Two\n",
+			expected: "--- a/doc.md\n+++ b/doc.md\n" +
+				"@@ -1,5 +1,5 @@\n-# one\n+# One\n 1\n 2\n 3\n-# two\n+# Two\n",
+		},
+		{
+			name: "no newline at end of file",
+			a:    "intro\n# the end",
+			b:    "intro\n# The End",
+			expected: "--- a/doc.md\n+++ b/doc.md\n" +
+				"@@ -1,2 +1,2 @@\n intro\n-# the end\n\\ No newline at end of file\n+# The End\n\\ No newline at end of file\n",
+		},
+		{
+			name: "insertion",
+			a:    "a\nc\n",
+			b:    "a\nb\nc\n",
+			expected: "--- a/doc.md\n+++ b/doc.md\n" +
+				"@@ -1,2 +1,3 @@\n a\n+b\n c\n",
+		},
+		{
+			name: "empty file",
+			a:    "",
+			b:    "title\n",
+			expected: "--- a/doc.md\n+++ b/doc.md\n" +
+				"@@ -0,0 +1 @@\n+title\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := string(Unified("doc.md", []byte(tt.a), []byte(tt.b)))
+			if result != tt.expected {
+				t.Errorf("Unified(%q, %q) = %q, want %q", tt.a, tt.b, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package document
+
+import (
+	"strings"
+)
+
+func AsciiDocHeadings(src []byte) []Heading {
+	var headings []Heading
+	var block string
+
+	for _, l := range splitLines(src) {
+		text := strings.TrimRight(l.text, " \t")
+
+		if block != "" {
+			if text == block {
+				block = ""
+			}
+			continue
+		}
+		if isAsciiDocDelimiter(text) {
+			block = text
+			continue
+		}
+		if strings.HasPrefix(text, "//") {
+			continue
+		}
+
+		if heading, ok := asciiDocHeading(l, text); ok {
+			headings = append(headings, heading)
+		}
+	}
+
+	return headings
+}
+
+func isAsciiDocDelimiter(text string) bool {
+	if strings.HasPrefix(text, "```") {
+		return true
+	}
+	if len(text) < 4 {
+		return false
+	}
+	switch text[0] {
+	case '-', '.', '+', '/':
+		return strings.Trim(text, text[:1]) == ""
+	}
+	return false
+}
+
+func asciiDocHeading(l line, text string) (Heading, bool) {
+	level := 0
+	for level < len(text) && text[level] == '=' {
+		level++
+	}
+	if level == 0 || level > 6 || level == len(text) || text[level] != ' ' {
+		return Heading{}, false
+	}
+
+	content := text[level:]
+	if closing := strings.Repeat("=", level); strings.HasSuffix(content, " "+closing) {
+		content = strings.TrimSuffix(content, closing)
+	}
+	title := strings.TrimSpace(content)
+	if title == "" {
+		return Heading{}, false
+	}
+
+	return Heading{
+		Line:  l.number,
+		Start: l.start + level + strings.Index(content, title),
+		Text:  title,
+	}, true
+}
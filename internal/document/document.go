@@ -0,0 +1,63 @@
+package document
+
+import (
+	"sort"
+)
+
+type Heading struct {
+	Line  int
+	Start int
+	Text  string
+}
+
+type Change struct {
+	Heading
+	Expected string
+}
+
+func Apply(src []byte, changes []Change) []byte {
+	sorted := make([]Change, len(changes))
+	copy(sorted, changes)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+
+	out := make([]byte, 0, len(src))
+	last := 0
+	for _, change := range sorted {
+		out = append(out, src[last:change.Start]...)
+		out = append(out, change.Expected...)
+		last = change.Start + len(change.Text)
+	}
+	out = append(out, src[last:]...)
+
+	return out
+}
+
+type line struct {
+	number int
+	start  int
+	text   string
+}
+
+func splitLines(src []byte) []line {
+	var lines []line
+	start := 0
+	number := 1
+
+	for start < len(src) {
+		end := start
+		for end < len(src) && src[end] != '\n' {
+			end++
+		}
+		text := string(src[start:end])
+		if len(text) > 0 && text[len(text)-1] == '\r' {
+			text = text[:len(text)-1]
+		}
+		lines = append(lines, line{number: number, start: start, text: text})
+		start = end + 1
+		number++
+	}
+
+	return lines
+}
@@ -0,0 +1,122 @@
+package document
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/keircn/gtl/pkg/titlecase"
+)
+
+type Format struct {
+	Name       string
+	Extensions []string
+	Headings   func(src []byte) []Heading
+	Title      func(text string, convert func(string) (string, error)) (string, error)
+}
+
+var (
+	MarkdownFormat = &Format{
+		Name:       "markdown",
+		Extensions: []string{".md", ".markdown", ".mdown", ".mkd"},
+		Headings:   MarkdownHeadings,
+		Title:      titleInline,
+	}
+	AsciiDocFormat = &Format{
+		Name:       "asciidoc",
+		Extensions: []string{".adoc", ".asciidoc", ".asc"},
+		Headings:   AsciiDocHeadings,
+		Title:      titlePlain,
+	}
+	RSTFormat = &Format{
+		Name:       "restructuredtext",
+		Extensions: []string{".rst", ".rest"},
+		Headings:   RSTHeadings,
+		Title:      titlePlain,
+	}
+	TextFormat = &Format{
+		Name:       "text",
+		Extensions: []string{".txt"},
+		Headings:   TextHeadings,
+		Title:      titlePlain,
+	}
+)
+
+func Formats() []*Format {
+	return []*Format{MarkdownFormat, AsciiDocFormat, RSTFormat, TextFormat}
+}
+
+func FormatNamed(name string) *Format {
+	for _, format := range Formats() {
+		if format.Name == name {
+			return format
+		}
+	}
+	return nil
+}
+
+func FormatFor(path string) *Format {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, format := range Formats() {
+		for _, e := range format.Extensions {
+			if e == ext {
+				return format
+			}
+		}
+	}
+	return nil
+}
+
+func WalkFormatFor(path string) *Format {
+	if format := FormatFor(path); format != TextFormat {
+		return format
+	}
+	return nil
+}
+
+func (f *Format) Changes(src []byte, convert func(string) (string, error)) ([]Change, error) {
+	var changes []Change
+
+	for _, heading := range f.Headings(src) {
+		expected, err := f.Title(heading.Text, convert)
+		if errors.Is(err, titlecase.ErrEmptyInput) {
+			continue
+		}
+		if err != nil {
+			return nil, &HeadingError{Line: heading.Line, Err: err}
+		}
+		if expected != heading.Text {
+			changes = append(changes, Change{Heading: heading, Expected: expected})
+		}
+	}
+
+	return changes, nil
+}
+
+func titlePlain(text string, convert func(string) (string, error)) (string, error) {
+	return convert(text)
+}
+
+func TextHeadings(src []byte) []Heading {
+	var headings []Heading
+
+	for _, l := range splitLines(src) {
+		text := strings.TrimRightFunc(l.text, unicode.IsSpace)
+		trimmed := strings.TrimLeftFunc(text, unicode.IsSpace)
+		if !strings.ContainsFunc(trimmed, isLetterOrDigit) {
+			continue
+		}
+		headings = append(headings, Heading{
+			Line:  l.number,
+			Start: l.start + len(text) - len(trimmed),
+			Text:  trimmed,
+		})
+	}
+
+	return headings
+}
+
+func isLetterOrDigit(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,145 @@
+package document
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormatFor(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected *Format
+	}{
+		{"README.md", MarkdownFormat},
+		{"docs/guide.MARKDOWN", MarkdownFormat},
+		{"titles.txt", TextFormat},
+		{"guide.adoc", AsciiDocFormat},
+		{"index.rst", RSTFormat},
+		{"main.go", nil},
+		{"Makefile", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if format := FormatFor(tt.path); format != tt.expected {
+				t.Errorf("FormatFor(%q) = %v, want %v", tt.path, format, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWalkFormatFor(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected *Format
+	}{
+		{"README.md", MarkdownFormat},
+		{"guide.adoc", AsciiDocFormat},
+		{"index.rst", RSTFormat},
+		{"requirements.txt", nil},
+		{"CMakeLists.txt", nil},
+		{"main.go", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if format := WalkFormatFor(tt.path); format != tt.expected {
+				t.Errorf("WalkFormatFor(%q) = %v, want %v", tt.path, format, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTextHeadings(t *testing.T) {
+	input := "the first title\n\n  an indented title  \n---\nlast one"
+	expected := []Heading{
+		{Line: 1, Start: 0, Text: "the first title"},
+		{Line: 3, Start: 19, Text: "an indented title"},
+		{Line: 5, Start: 43, Text: "last one"},
+	}
+
+	headings := TextHeadings([]byte(input))
+	if !reflect.DeepEqual(headings, expected) {
+		t.Errorf("TextHeadings(%q) = %#v, want %#v", input, headings, expected)
+	}
+}
+
+func TestAsciiDocHeadings(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Heading
+	}{
+		{
+			name:  "section titles",
+			input: "= document title\n:toc:\n\n== first section\n\n=== nested ===\n",
+			expected: []Heading{
+				{Line: 1, Start: 2, Text: "document title"},
+				{Line: 4, Start: 27, Text: "first section"},
+				{Line: 6, Start: 46, Text: "nested"},
+			},
+		},
+		{
+			name:     "blocks skipped",
+			input:    "----\n== not a heading\n----\n////\n= nor this\n////\n// = comment\n====\n",
+			expected: nil,
+		},
+		{
+			name:     "not headings",
+			input:    "==no space\n=======\n======= too deep\n",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headings := AsciiDocHeadings([]byte(tt.input))
+			if !reflect.DeepEqual(headings, tt.expected) {
+				t.Errorf("AsciiDocHeadings(%q) = %#v, want %#v", tt.input, headings, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRSTHeadings(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Heading
+	}{
+		{
+			name:  "underlined titles",
+			input: "document title\n==============\n\nfirst section\n-------------\n",
+			expected: []Heading{
+				{Line: 1, Start: 0, Text: "document title"},
+				{Line: 4, Start: 31, Text: "first section"},
+			},
+		},
+		{
+			name:  "overlined title",
+			input: "=========\n the title\n=========\n",
+			expected: []Heading{
+				{Line: 2, Start: 11, Text: "the title"},
+			},
+		},
+		{
+			name:     "underline too short",
+			input:    "a long title\n====\n",
+			expected: nil,
+		},
+		{
+			name:     "not headings",
+			input:    "paragraph text\ncontinues here\n--------------\n\n    indented\n    --------\n\n=====  =====\nA      B\n=====  =====\n",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headings := RSTHeadings([]byte(tt.input))
+			if !reflect.DeepEqual(headings, tt.expected) {
+				t.Errorf("RSTHeadings(%q) = %#v, want %#v", tt.input, headings, tt.expected)
+			}
+		})
+	}
+}
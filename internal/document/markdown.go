@@ -0,0 +1,336 @@
+package document
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const mask = "\x00"
+
+var errMaskMismatch = errors.New("heading changed protected text")
+
+func Markdown(src []byte, convert func(string) (string, error)) ([]Change, error) {
+	return MarkdownFormat.Changes(src, convert)
+}
+
+type HeadingError struct {
+	Line int
+	Err  error
+}
+
+func (e *HeadingError) Error() string {
+	return "line " + strconv.Itoa(e.Line) + ": " + e.Err.Error()
+}
+
+func (e *HeadingError) Unwrap() error {
+	return e.Err
+}
+
+func MarkdownHeadings(src []byte) []Heading {
+	var headings []Heading
+	lines := splitLines(src)
+
+	var fence string
+	paragraphStart := -1
+
+	for i := 0; i < len(lines); i++ {
+		l := lines[i]
+
+		if i == 0 && strings.TrimRight(l.text, " \t") == "---" {
+			for i++; i < len(lines); i++ {
+				end := strings.TrimRight(lines[i].text, " \t")
+				if end == "---" || end == "..." {
+					break
+				}
+			}
+			continue
+		}
+
+		if fence != "" {
+			if closesFence(l.text, fence) {
+				fence = ""
+			}
+			continue
+		}
+
+		if marker := openingFence(l.text); marker != "" {
+			fence = marker
+			paragraphStart = -1
+			continue
+		}
+
+		if heading, ok := atxHeading(l); ok {
+			if heading.Text != "" {
+				headings = append(headings, heading)
+			}
+			paragraphStart = -1
+			continue
+		}
+
+		if strings.TrimSpace(l.text) == "" {
+			paragraphStart = -1
+			continue
+		}
+
+		if paragraphStart == i-1 && isSetextUnderline(l.text) {
+			prev := lines[i-1]
+			text := strings.TrimSpace(prev.text)
+			headings = append(headings, Heading{
+				Line:  prev.number,
+				Start: prev.start + strings.Index(prev.text, text),
+				Text:  text,
+			})
+			paragraphStart = -1
+			continue
+		}
+
+		switch {
+		case paragraphStart == -1 && startsParagraph(l.text):
+			paragraphStart = i
+		case isSetextUnderline(l.text):
+			paragraphStart = -1
+		default:
+			paragraphStart = -2
+		}
+	}
+
+	return headings
+}
+
+func indentation(text string) int {
+	n := 0
+	for n < len(text) && text[n] == ' ' {
+		n++
+	}
+	return n
+}
+
+func openingFence(text string) string {
+	indent := indentation(text)
+	if indent > 3 {
+		return ""
+	}
+
+	rest := text[indent:]
+	for _, char := range []byte{'`', '~'} {
+		n := 0
+		for n < len(rest) && rest[n] == char {
+			n++
+		}
+		if n < 3 {
+			continue
+		}
+		if char == '`' && strings.Contains(rest[n:], "`") {
+			return ""
+		}
+		return rest[:n]
+	}
+
+	return ""
+}
+
+func closesFence(text, fence string) bool {
+	indent := indentation(text)
+	if indent > 3 {
+		return false
+	}
+
+	rest := strings.TrimRight(text[indent:], " \t")
+	if len(rest) < len(fence) {
+		return false
+	}
+	return strings.Trim(rest, fence[:1]) == ""
+}
+
+func atxHeading(l line) (Heading, bool) {
+	indent := indentation(l.text)
+	if indent > 3 {
+		return Heading{}, false
+	}
+
+	rest := l.text[indent:]
+	level := 0
+	for level < len(rest) && rest[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 {
+		return Heading{}, false
+	}
+	if level < len(rest) && rest[level] != ' ' && rest[level] != '\t' {
+		return Heading{}, false
+	}
+
+	content := rest[level:]
+	content = strings.TrimRight(content, " \t")
+	if trimmed := strings.TrimRight(content, "#"); trimmed != content {
+		if trimmed == "" || strings.HasSuffix(trimmed, " ") || strings.HasSuffix(trimmed, "\t") {
+			content = strings.TrimRight(trimmed, " \t")
+		}
+	}
+
+	text := strings.TrimLeft(content, " \t")
+	start := l.start + indent + level + len(content) - len(text)
+
+	return Heading{Line: l.number, Start: start, Text: text}, true
+}
+
+func isSetextUnderline(text string) bool {
+	indent := indentation(text)
+	if indent > 3 {
+		return false
+	}
+
+	rest := strings.TrimRight(text[indent:], " \t")
+	if rest == "" {
+		return false
+	}
+	return strings.Trim(rest, "=") == "" || strings.Trim(rest, "-") == ""
+}
+
+func startsParagraph(text string) bool {
+	if indentation(text) > 3 {
+		return false
+	}
+
+	rest := strings.TrimLeft(text, " ")
+	switch {
+	case strings.HasPrefix(rest, ">"),
+		strings.HasPrefix(rest, "<"),
+		strings.HasPrefix(rest, "|"),
+		strings.HasPrefix(rest, "- "),
+		strings.HasPrefix(rest, "* "),
+		strings.HasPrefix(rest, "+ "):
+		return false
+	}
+
+	digits := 0
+	for digits < len(rest) && rest[digits] >= '0' && rest[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && digits < len(rest) && (rest[digits] == '.' || rest[digits] == ')') {
+		return false
+	}
+
+	return !isSetextUnderline(text)
+}
+
+func titleInline(text string, convert func(string) (string, error)) (string, error) {
+	if strings.Contains(text, mask) {
+		return text, nil
+	}
+
+	var masked strings.Builder
+	var protected []string
+
+	for i := 0; i < len(text); {
+		// Code spans are left to convert, which keeps them unchanged but
+		// still counts them as words, so "pass it to `f`" keeps "to" lowercase.
+		if text[i] == '`' {
+			end := codeSpanEnd(text, i)
+			masked.WriteString(text[i:end])
+			i = end
+			continue
+		}
+		if end := protectedInline(text, i); end > i {
+			protected = append(protected, text[i:end])
+			masked.WriteString(mask)
+			i = end
+			continue
+		}
+		masked.WriteByte(text[i])
+		i++
+	}
+
+	converted, err := convert(masked.String())
+	if err != nil {
+		return "", err
+	}
+
+	parts := strings.Split(converted, mask)
+	if len(parts) != len(protected)+1 {
+		return "", errMaskMismatch
+	}
+
+	var result strings.Builder
+	for i, part := range parts {
+		result.WriteString(part)
+		if i < len(protected) {
+			result.WriteString(protected[i])
+		}
+	}
+
+	return result.String(), nil
+}
+
+func codeSpanEnd(text string, i int) int {
+	n := 0
+	for i+n < len(text) && text[i+n] == '`' {
+		n++
+	}
+	ticks := text[i : i+n]
+	for j := i + n; j < len(text); {
+		k := strings.Index(text[j:], ticks)
+		if k < 0 {
+			break
+		}
+		end := j + k + n
+		if end >= len(text) || text[end] != '`' {
+			return end
+		}
+		for end < len(text) && text[end] == '`' {
+			end++
+		}
+		j = end
+	}
+	return i + n
+}
+
+func protectedInline(text string, i int) int {
+	switch text[i] {
+	case '\\':
+		if i+1 < len(text) {
+			return i + 2
+		}
+	case '(':
+		if i > 0 && text[i-1] == ']' {
+			return matchingParen(text, i)
+		}
+	case '[':
+		if i > 0 && text[i-1] == ']' {
+			if k := strings.IndexByte(text[i:], ']'); k >= 0 {
+				return i + k + 1
+			}
+		}
+	case '<':
+		if i+1 < len(text) && (isASCIILetter(text[i+1]) || text[i+1] == '/' || text[i+1] == '!') {
+			if k := strings.IndexByte(text[i:], '>'); k >= 0 {
+				return i + k + 1
+			}
+		}
+	}
+
+	return i
+}
+
+func matchingParen(text string, open int) int {
+	depth := 0
+	for i := open; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return open
+}
+
+func isASCIILetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
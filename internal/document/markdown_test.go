@@ -0,0 +1,123 @@
+package document
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keircn/gtl/pkg/titlecase"
+)
+
+func TestMarkdownHeadings(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Heading
+	}{
+		{
+			name:  "atx headings",
+			input: "# first heading\n\nbody text\n\n### third level ###\n",
+			expected: []Heading{
+				{Line: 1, Start: 2, Text: "first heading"},
+				{Line: 5, Start: 32, Text: "third level"},
+			},
+		},
+		{
+			name:  "setext headings",
+			input: "first heading\n=============\n\nsecond heading\n---\n",
+			expected: []Heading{
+				{Line: 1, Start: 0, Text: "first heading"},
+				{Line: 4, Start: 29, Text: "second heading"},
+			},
+		},
+		{
+			name:     "code fences skipped",
+			input:    "```sh\n# not a heading\n```\n~~~\n## nor this\n~~~\n",
+			expected: nil,
+		},
+		{
+			name:     "not headings",
+			input:    "#hashtag\n    # indented code\nparagraph line\ncontinues here\n---\n- item\n---\n",
+			expected: nil,
+		},
+		{
+			name:     "front matter skipped",
+			input:    "---\ntitle: front matter\n---\n",
+			expected: nil,
+		},
+		{
+			name:  "crlf line endings",
+			input: "# first heading\r\nsecond heading\r\n===\r\n",
+			expected: []Heading{
+				{Line: 1, Start: 2, Text: "first heading"},
+				{Line: 2, Start: 17, Text: "second heading"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headings := MarkdownHeadings([]byte(tt.input))
+			if !reflect.DeepEqual(headings, tt.expected) {
+				t.Errorf("MarkdownHeadings(%q) = %+v, want %+v", tt.input, headings, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "headings rewritten",
+			input:    "# the lord of the rings\n\nthe body stays as it is\n\nreturn of the king\n---\n",
+			expected: "# The Lord of the Rings\n\nthe body stays as it is\n\nReturn of the King\n---\n",
+		},
+		{
+			name:     "inline code untouched",
+			input:    "## configuring the `max_conns` option\n",
+			expected: "## Configuring the `max_conns` Option\n",
+		},
+		{
+			name:     "trailing inline code",
+			input:    "## what to pass to `configure`\n",
+			expected: "## What to Pass to `configure`\n",
+		},
+		{
+			name:     "link destinations untouched",
+			input:    "# see [the guide](docs/Setup.md) for more\n",
+			expected: "# See [the Guide](docs/Setup.md) for More\n",
+		},
+		{
+			name:     "closing sequence kept",
+			input:    "## getting started ##\n",
+			expected: "## Getting Started ##\n",
+		},
+		{
+			name:     "code block untouched",
+			input:    "```\n# a comment\n```\n# a heading\n",
+			expected: "```\n# a comment\n```\n# A Heading\n",
+		},
+		{
+			name:     "headings without words skipped",
+			input:    "# the end\n\n## ???\n\n# <img src=\"x\">\n\n## 😀\n\n## a guide\n",
+			expected: "# The End\n\n## ???\n\n# <img src=\"x\">\n\n## 😀\n\n## A Guide\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := []byte(tt.input)
+			changes, err := Markdown(src, titlecase.ToTitleCase)
+			if err != nil {
+				t.Errorf("Markdown(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if result := string(Apply(src, changes)); result != tt.expected {
+				t.Errorf("Markdown(%q) applied = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
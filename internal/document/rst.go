@@ -0,0 +1,57 @@
+package document
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const rstAdornments = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+func RSTHeadings(src []byte) []Heading {
+	var headings []Heading
+	lines := splitLines(src)
+
+	for i := 0; i+1 < len(lines); i++ {
+		l := lines[i]
+		title := strings.TrimSpace(l.text)
+		if title == "" || rstAdornment(l.text) != 0 || strings.HasPrefix(title, "..") {
+			continue
+		}
+
+		char := rstAdornment(lines[i+1].text)
+		if char == 0 || len(strings.TrimRight(lines[i+1].text, " \t")) < utf8.RuneCountInString(title) {
+			continue
+		}
+
+		before := i - 1
+		overlined := before >= 0 && rstAdornment(lines[before].text) == char
+		if overlined {
+			before--
+		} else if indentation(l.text) > 0 {
+			continue
+		}
+		if before >= 0 && strings.TrimSpace(lines[before].text) != "" {
+			continue
+		}
+
+		headings = append(headings, Heading{
+			Line:  l.number,
+			Start: l.start + strings.Index(l.text, title),
+			Text:  title,
+		})
+		i++
+	}
+
+	return headings
+}
+
+func rstAdornment(text string) byte {
+	text = strings.TrimRight(text, " \t")
+	if len(text) < 2 || !strings.ContainsRune(rstAdornments, rune(text[0])) {
+		return 0
+	}
+	if strings.Trim(text, text[:1]) != "" {
+		return 0
+	}
+	return text[0]
+}
@@ -0,0 +1,258 @@
+package files
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type Options struct {
+	Include []string
+	Exclude []string
+	Known   func(path string) bool
+}
+
+func Expand(args []string, opts Options) ([]string, error) {
+	e := &expander{
+		include: parsePatterns(opts.Include),
+		exclude: parsePatterns(opts.Exclude),
+		known:   opts.Known,
+		ignores: make(map[string][]pattern),
+		seen:    make(map[string]bool),
+	}
+
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		switch {
+		case err == nil && !info.IsDir():
+			e.add(arg)
+		case err == nil:
+			if err := e.walk(arg, nil); err != nil {
+				return nil, err
+			}
+		case errors.Is(err, fs.ErrNotExist) && hasMeta(arg):
+			base, glob := splitGlob(arg)
+			if err := e.walk(base, glob); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, err
+		}
+	}
+
+	return e.paths, nil
+}
+
+func IsPath(arg string) bool {
+	if _, err := os.Stat(arg); err == nil {
+		return true
+	}
+	if !hasMeta(arg) {
+		return false
+	}
+	matches, err := filepath.Glob(arg)
+	if err == nil && len(matches) > 0 {
+		return true
+	}
+	base, _ := splitGlob(arg)
+	return base != "." && strings.Contains(arg, "**") && isDir(base)
+}
+
+type expander struct {
+	include []pattern
+	exclude []pattern
+	known   func(path string) bool
+	ignores map[string][]pattern
+	seen    map[string]bool
+	paths   []string
+}
+
+func (e *expander) add(path string) {
+	clean := filepath.Clean(path)
+	if e.seen[clean] {
+		return
+	}
+	e.seen[clean] = true
+	e.paths = append(e.paths, path)
+}
+
+func (e *expander) walk(root string, glob []string) error {
+	top, err := repoRoot(root)
+	if err != nil {
+		return err
+	}
+
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if d.Name() == ".git" || matchAny(e.exclude, rel, true) {
+				return filepath.SkipDir
+			}
+			ignored, err := e.ignored(top, path, true)
+			if err != nil {
+				return err
+			}
+			if ignored {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if matchAny(e.exclude, rel, false) {
+			return nil
+		}
+		ignored, err := e.ignored(top, path, false)
+		if err != nil || ignored {
+			return err
+		}
+
+		switch {
+		case glob != nil:
+			if !matchSegments(glob, strings.Split(rel, "/")) {
+				return nil
+			}
+			// A glob such as docs/** names no file type, so it picks files
+			// like a directory walk does.
+			if last := glob[len(glob)-1]; (last == "*" || last == "**") && e.known != nil && !e.known(path) {
+				return nil
+			}
+		case len(e.include) > 0:
+			if !matchAny(e.include, rel, false) {
+				return nil
+			}
+		case e.known != nil && !e.known(path):
+			return nil
+		}
+
+		e.add(path)
+		return nil
+	})
+}
+
+func (e *expander) ignored(top, path string, isDir bool) (bool, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false, err
+	}
+
+	var dirs []string
+	for dir := filepath.Dir(abs); ; dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+		if dir == top || dir == filepath.Dir(dir) {
+			break
+		}
+	}
+
+	ignored := false
+	for i := len(dirs) - 1; i >= 0; i-- {
+		patterns, err := e.gitignore(dirs[i])
+		if err != nil {
+			return false, err
+		}
+		rel, err := filepath.Rel(dirs[i], abs)
+		if err != nil {
+			return false, err
+		}
+		rel = filepath.ToSlash(rel)
+		for _, p := range patterns {
+			if p.match(rel, isDir) {
+				ignored = !p.negate
+			}
+		}
+	}
+
+	return ignored, nil
+}
+
+func (e *expander) gitignore(dir string) ([]pattern, error) {
+	if patterns, ok := e.ignores[dir]; ok {
+		return patterns, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	var patterns []pattern
+	for _, line := range strings.Split(string(data), "\n") {
+		if p, ok := parsePattern(line); ok {
+			patterns = append(patterns, p)
+		}
+	}
+	e.ignores[dir] = patterns
+
+	return patterns, nil
+}
+
+func repoRoot(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	for dir := abs; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir, nil
+		}
+		if dir == filepath.Dir(dir) {
+			return abs, nil
+		}
+	}
+}
+
+func splitGlob(arg string) (string, []string) {
+	segments := strings.Split(filepath.ToSlash(arg), "/")
+	for i, segment := range segments {
+		if hasMeta(segment) || segment == "**" {
+			base := strings.Join(segments[:i], "/")
+			if base == "" && i > 0 {
+				base = "/"
+			}
+			if base == "" {
+				base = "."
+			}
+			return filepath.FromSlash(base), segments[i:]
+		}
+	}
+	return arg, nil
+}
+
+func parsePatterns(texts []string) []pattern {
+	var patterns []pattern
+	for _, text := range texts {
+		if p, ok := parsePattern(text); ok {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+func matchAny(patterns []pattern, rel string, isDir bool) bool {
+	matched := false
+	for _, p := range patterns {
+		if p.match(rel, isDir) {
+			matched = !p.negate
+		}
+	}
+	return matched
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,168 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		".git/HEAD":           "ref: refs/heads/main\n",
+		".gitignore":          "build/\n*.tmp.md\n",
+		"docs/a.md":           "# a\n",
+		"docs/b.txt":          "b\n",
+		"docs/code.go":        "package docs\n",
+		"docs/draft.tmp.md":   "# draft\n",
+		"docs/sub/c.md":       "# c\n",
+		"docs/sub/.gitignore": "*.md\n!keep.md\n",
+		"docs/sub/keep.md":    "# keep\n",
+		"build/d.md":          "# d\n",
+		"vendor/e.md":         "# e\n",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	known := func(path string) bool {
+		ext := filepath.Ext(path)
+		return ext == ".md" || ext == ".txt"
+	}
+	markdown := func(path string) bool {
+		return filepath.Ext(path) == ".md"
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		opts     Options
+		expected []string
+	}{
+		{
+			name:     "directory",
+			args:     []string{"."},
+			opts:     Options{Known: known},
+			expected: []string{"docs/a.md", "docs/b.txt", "docs/sub/keep.md", "vendor/e.md"},
+		},
+		{
+			name:     "exclude",
+			args:     []string{"."},
+			opts:     Options{Known: known, Exclude: []string{"vendor", "*.txt"}},
+			expected: []string{"docs/a.md", "docs/sub/keep.md"},
+		},
+		{
+			name:     "include",
+			args:     []string{"docs"},
+			opts:     Options{Known: known, Include: []string{"*.go"}},
+			expected: []string{"docs/code.go"},
+		},
+		{
+			name:     "glob",
+			args:     []string{"docs/**/*.md"},
+			opts:     Options{Known: known},
+			expected: []string{"docs/a.md", "docs/sub/keep.md"},
+		},
+		{
+			name:     "glob without extension",
+			args:     []string{"docs/**"},
+			opts:     Options{Known: markdown},
+			expected: []string{"docs/a.md", "docs/sub/keep.md"},
+		},
+		{
+			name:     "glob with extension",
+			args:     []string{"docs/*.txt"},
+			opts:     Options{Known: markdown},
+			expected: []string{"docs/b.txt"},
+		},
+		{
+			name:     "directory skips unknown files",
+			args:     []string{"docs"},
+			opts:     Options{Known: markdown},
+			expected: []string{"docs/a.md", "docs/sub/keep.md"},
+		},
+		{
+			name:     "explicit file",
+			args:     []string{"build/d.md", "docs/code.go", "build/d.md"},
+			opts:     Options{Known: known},
+			expected: []string{"build/d.md", "docs/code.go"},
+		},
+	}
+
+	chdir(t, root)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := Expand(tt.args, tt.opts)
+			if err != nil {
+				t.Fatalf("Expand(%q) returned unexpected error: %v", tt.args, err)
+			}
+			for i, path := range paths {
+				paths[i] = filepath.ToSlash(path)
+			}
+			if !reflect.DeepEqual(paths, tt.expected) {
+				t.Errorf("Expand(%q) = %q, want %q", tt.args, paths, tt.expected)
+			}
+		})
+	}
+
+	if _, err := Expand([]string{"missing.md"}, Options{}); err == nil {
+		t.Error("Expand(missing.md) returned no error")
+	}
+}
+
+func TestPatternMatch(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		path     string
+		isDir    bool
+		expected bool
+	}{
+		{"*.md", "README.md", false, true},
+		{"*.md", "docs/guide.md", false, true},
+		{"*.md", "docs/guide.txt", false, false},
+		{"build/", "build", true, true},
+		{"build/", "build", false, false},
+		{"/docs/*.md", "docs/guide.md", false, true},
+		{"/docs/*.md", "docs/sub/guide.md", false, false},
+		{"docs/**/*.md", "docs/guide.md", false, true},
+		{"docs/**/*.md", "docs/a/b/guide.md", false, true},
+		{"**/drafts", "a/drafts", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			p, ok := parsePattern(tt.pattern)
+			if !ok {
+				t.Fatalf("parsePattern(%q) failed", tt.pattern)
+			}
+			if got := p.match(tt.path, tt.isDir); got != tt.expected {
+				t.Errorf("match(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.expected)
+			}
+		})
+	}
+
+	for _, text := range []string{"", "  ", "# comment", "/"} {
+		if _, ok := parsePattern(text); ok {
+			t.Errorf("parsePattern(%q) succeeded, want skipped", strings.TrimSpace(text))
+		}
+	}
+}
+
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
@@ -0,0 +1,82 @@
+package files
+
+import (
+	"path"
+	"strings"
+)
+
+type pattern struct {
+	segments []string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+func parsePattern(text string) (pattern, bool) {
+	text = strings.TrimRight(text, " \t\r")
+	if text == "" || strings.HasPrefix(text, "#") {
+		return pattern{}, false
+	}
+
+	var p pattern
+	if strings.HasPrefix(text, "!") {
+		p.negate = true
+		text = text[1:]
+	}
+	text = strings.TrimPrefix(text, `\`)
+	if strings.HasSuffix(text, "/") {
+		p.dirOnly = true
+		text = strings.TrimRight(text, "/")
+	}
+	if strings.Contains(text, "/") {
+		p.anchored = true
+		text = strings.TrimPrefix(text, "/")
+	}
+	if text == "" {
+		return pattern{}, false
+	}
+
+	p.segments = strings.Split(text, "/")
+	return p, true
+}
+
+func (p pattern) match(rel string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	segments := strings.Split(rel, "/")
+	if !p.anchored {
+		return matchSegment(p.segments[0], segments[len(segments)-1])
+	}
+	return matchSegments(p.segments, segments)
+}
+
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 || !matchSegment(pattern[0], segments[0]) {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
+
+func matchSegment(pattern, name string) bool {
+	ok, err := path.Match(pattern, name)
+	return err == nil && ok
+}
+
+func hasMeta(text string) bool {
+	return strings.ContainsAny(text, "*?[")
+}
@@ -0,0 +1,57 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func WriteFile(path string, data []byte, backup string) error {
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		return err
+	}
+
+	if backup != "" {
+		original, err := os.ReadFile(target)
+		if err != nil {
+			return err
+		}
+		if err := writeAtomic(target+backup, original, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+
+	return writeAtomic(target, data, info.Mode().Perm())
+}
+
+func writeAtomic(path string, data []byte, perm os.FileMode) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,55 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "doc.md")
+	if err := os.WriteFile(path, []byte("# the end\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link.md")
+	if err := os.Symlink("doc.md", link); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFile(link, []byte("# The End\n"), ".orig"); err != nil {
+		t.Fatalf("WriteFile returned unexpected error: %v", err)
+	}
+
+	for name, expected := range map[string]string{
+		"doc.md":      "# The End\n",
+		"doc.md.orig": "# the end\n",
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != expected {
+			t.Errorf("%s = %q, want %q", name, data, expected)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, want %v", info.Mode().Perm(), os.FileMode(0o600))
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("link.md is no longer a symlink")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Errorf("directory has %d entries, want 3 (temporary file left behind?)", len(entries))
+	}
+}
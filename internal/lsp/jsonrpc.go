@@ -0,0 +1,123 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+)
+
+const (
+	codeParseError           = -32700
+	codeInvalidRequest       = -32600
+	codeMethodNotFound       = -32601
+	codeInvalidParams        = -32602
+	codeServerNotInitialized = -32002
+)
+
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *responseError  `json:"error,omitempty"`
+}
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *responseError) Error() string {
+	return e.Message
+}
+
+type conn struct {
+	reader *textproto.Reader
+	writer io.Writer
+	nextID int
+}
+
+func newConn(r io.Reader, w io.Writer) *conn {
+	return &conn{reader: textproto.NewReader(bufio.NewReader(r)), writer: w}
+}
+
+func (c *conn) read() (*message, error) {
+	header, err := c.reader.ReadMIMEHeader()
+	if err != nil {
+		if errors.Is(err, io.EOF) && len(header) == 0 {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid Content-Length %q", header.Get("Content-Length"))
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.reader.R, body); err != nil {
+		return nil, err
+	}
+
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return &msg, &responseError{Code: codeParseError, Message: err.Error()}
+	}
+	return &msg, nil
+}
+
+func (c *conn) write(msg *message) error {
+	msg.JSONRPC = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(c.writer, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = c.writer.Write(body)
+	return err
+}
+
+func (c *conn) reply(id json.RawMessage, result any, err error) error {
+	msg := &message{ID: id}
+	if err != nil {
+		var rpcErr *responseError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &responseError{Code: codeInvalidRequest, Message: err.Error()}
+		}
+		msg.Error = rpcErr
+		return c.write(msg)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	msg.Result = data
+	return c.write(msg)
+}
+
+func (c *conn) notify(method string, params any) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return c.write(&message{Method: method, Params: data})
+}
+
+func (c *conn) request(method string, params any) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	c.nextID++
+	return c.write(&message{ID: json.RawMessage(strconv.Itoa(c.nextID)), Method: method, Params: data})
+}
@@ -0,0 +1,443 @@
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/keircn/gtl/internal/config"
+	"github.com/keircn/gtl/internal/document"
+	"github.com/keircn/gtl/pkg/titlecase"
+	"github.com/keircn/gtl/pkg/version"
+)
+
+var errExitWithoutShutdown = errors.New("exit received before shutdown")
+
+type server struct {
+	conn        *conn
+	root        string
+	watch       bool
+	initialized bool
+	shutdown    bool
+	docs        map[string]*textDocument
+	titlers     map[string]*titlecase.Titler
+}
+
+type textDocument struct {
+	uri     string
+	version int
+	text    string
+	format  *document.Format
+}
+
+type finding struct {
+	heading  document.Heading
+	rng      textRange
+	expected string
+	err      error
+}
+
+func Serve(r io.Reader, w io.Writer) error {
+	s := &server{
+		conn:    newConn(r, w),
+		docs:    make(map[string]*textDocument),
+		titlers: make(map[string]*titlecase.Titler),
+	}
+
+	for {
+		msg, err := s.conn.read()
+		var rpcErr *responseError
+		if errors.As(err, &rpcErr) {
+			if err := s.conn.reply(json.RawMessage("null"), nil, rpcErr); err != nil {
+				return err
+			}
+			continue
+		}
+		if err == io.EOF && s.shutdown {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return errExitWithoutShutdown
+			}
+			return nil
+		}
+		if err := s.handle(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *server) handle(msg *message) error {
+	if msg.Method == "" {
+		return nil
+	}
+
+	result, err := s.dispatch(msg.Method, msg.Params)
+	if msg.ID == nil {
+		if err != nil && s.initialized {
+			return s.conn.notify("window/logMessage", showMessageParams{Type: messageError, Message: err.Error()})
+		}
+		return nil
+	}
+	return s.conn.reply(msg.ID, result, err)
+}
+
+func (s *server) dispatch(method string, params json.RawMessage) (any, error) {
+	switch {
+	case method == "initialize":
+		var p initializeParams
+		if err := decode(params, &p); err != nil {
+			return nil, err
+		}
+		return s.initialize(p), nil
+	case !s.initialized:
+		return nil, &responseError{Code: codeServerNotInitialized, Message: "server not initialized"}
+	case s.shutdown:
+		return nil, &responseError{Code: codeInvalidRequest, Message: "server is shutting down"}
+	}
+
+	switch method {
+	case "initialized":
+		return nil, s.register()
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var p didOpenParams
+		if err := decode(params, &p); err != nil {
+			return nil, err
+		}
+		format := formatFor(p.TextDocument.LanguageID, p.TextDocument.URI)
+		if format == nil {
+			return nil, nil
+		}
+		doc := &textDocument{uri: p.TextDocument.URI, version: p.TextDocument.Version, text: p.TextDocument.Text, format: format}
+		s.docs[doc.uri] = doc
+		return nil, s.publish(doc)
+	case "textDocument/didChange":
+		var p didChangeParams
+		if err := decode(params, &p); err != nil {
+			return nil, err
+		}
+		doc, ok := s.docs[p.TextDocument.URI]
+		if !ok || len(p.ContentChanges) == 0 {
+			return nil, nil
+		}
+		doc.version = p.TextDocument.Version
+		doc.text = p.ContentChanges[len(p.ContentChanges)-1].Text
+		return nil, s.publish(doc)
+	case "textDocument/didSave":
+		var p didSaveParams
+		if err := decode(params, &p); err != nil {
+			return nil, err
+		}
+		if isConfig(p.TextDocument.URI) {
+			return nil, s.reload()
+		}
+		return nil, nil
+	case "textDocument/didClose":
+		var p didCloseParams
+		if err := decode(params, &p); err != nil {
+			return nil, err
+		}
+		if _, ok := s.docs[p.TextDocument.URI]; !ok {
+			return nil, nil
+		}
+		delete(s.docs, p.TextDocument.URI)
+		return nil, s.conn.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+			URI:         p.TextDocument.URI,
+			Diagnostics: []diagnostic{},
+		})
+	case "workspace/didChangeWatchedFiles":
+		var p didChangeWatchedFilesParams
+		if err := decode(params, &p); err != nil {
+			return nil, err
+		}
+		for _, change := range p.Changes {
+			if isConfig(change.URI) {
+				return nil, s.reload()
+			}
+		}
+		return nil, nil
+	case "workspace/didChangeConfiguration":
+		return nil, s.reload()
+	case "textDocument/codeAction":
+		var p codeActionParams
+		if err := decode(params, &p); err != nil {
+			return nil, err
+		}
+		return s.codeActions(p)
+	}
+
+	if strings.HasPrefix(method, "$/") {
+		return nil, nil
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + method}
+}
+
+func (s *server) initialize(p initializeParams) initializeResult {
+	s.initialized = true
+	s.watch = p.Capabilities.Workspace.DidChangeWatchedFiles.DynamicRegistration
+	if root, ok := uriPath(p.RootURI); ok {
+		s.root = root
+	} else {
+		s.root = p.RootPath
+	}
+
+	return initializeResult{
+		Capabilities: serverCapabilities{
+			TextDocumentSync:   textDocumentSyncOptions{OpenClose: true, Change: syncFull, Save: true},
+			CodeActionProvider: codeActionOptions{CodeActionKinds: []string{kindQuickFix, kindFixAll}},
+		},
+		ServerInfo: serverInfo{Name: "gtl", Version: version.ShortVersion()},
+	}
+}
+
+func (s *server) register() error {
+	if !s.watch {
+		return nil
+	}
+
+	return s.conn.request("client/registerCapability", registrationParams{
+		Registrations: []registration{{
+			ID:     "gtl-config",
+			Method: "workspace/didChangeWatchedFiles",
+			RegisterOptions: map[string][]fileSystemWatcher{
+				"watchers": {{GlobPattern: "**/" + config.FileName}},
+			},
+		}},
+	})
+}
+
+func (s *server) reload() error {
+	s.titlers = make(map[string]*titlecase.Titler)
+	for _, doc := range s.docs {
+		if err := s.publish(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *server) publish(doc *textDocument) error {
+	findings, err := s.findings(doc)
+	if err != nil {
+		return err
+	}
+
+	diagnostics := []diagnostic{}
+	for _, f := range findings {
+		diagnostics = append(diagnostics, f.diagnostic())
+	}
+
+	return s.conn.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         doc.uri,
+		Version:     doc.version,
+		Diagnostics: diagnostics,
+	})
+}
+
+func (s *server) codeActions(p codeActionParams) ([]codeAction, error) {
+	actions := []codeAction{}
+
+	doc, ok := s.docs[p.TextDocument.URI]
+	if !ok {
+		return actions, nil
+	}
+	findings, err := s.findings(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []textEdit
+	for _, f := range findings {
+		if f.err != nil {
+			continue
+		}
+		edit := textEdit{Range: f.rng, NewText: f.expected}
+		all = append(all, edit)
+
+		if !wants(p.Context.Only, kindQuickFix) || f.rng.End.Line < p.Range.Start.Line || f.rng.Start.Line > p.Range.End.Line {
+			continue
+		}
+		actions = append(actions, codeAction{
+			Title:       fmt.Sprintf("Change to %q", f.expected),
+			Kind:        kindQuickFix,
+			Diagnostics: []diagnostic{f.diagnostic()},
+			IsPreferred: true,
+			Edit:        &workspaceEdit{Changes: map[string][]textEdit{doc.uri: {edit}}},
+		})
+	}
+
+	if len(all) > 0 && wants(p.Context.Only, kindFixAll) {
+		actions = append(actions, codeAction{
+			Title: "Title-case all headings",
+			Kind:  kindFixAll,
+			Edit:  &workspaceEdit{Changes: map[string][]textEdit{doc.uri: all}},
+		})
+	}
+
+	return actions, nil
+}
+
+func (s *server) findings(doc *textDocument) ([]finding, error) {
+	titler, err := s.titler(doc.uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []finding
+	for _, heading := range doc.format.Headings([]byte(doc.text)) {
+		expected, err := doc.format.Title(heading.Text, titler.Title)
+		if errors.Is(err, titlecase.ErrEmptyInput) || err == nil && expected == heading.Text {
+			continue
+		}
+		findings = append(findings, finding{
+			heading:  heading,
+			rng:      headingRange(doc.text, heading),
+			expected: expected,
+			err:      err,
+		})
+	}
+
+	return findings, nil
+}
+
+func (s *server) titler(uri string) (*titlecase.Titler, error) {
+	dir := s.root
+	if p, ok := uriPath(uri); ok {
+		dir = filepath.Dir(p)
+	}
+	if dir == "" {
+		dir = "."
+	}
+
+	found, err := config.Find(dir)
+	if err != nil {
+		return nil, err
+	}
+	if titler, ok := s.titlers[found]; ok {
+		return titler, nil
+	}
+
+	titler, err := loadTitler(found)
+	if err != nil {
+		titler = titlecase.New(titlecase.Options{})
+		msg := showMessageParams{Type: messageError, Message: "gtl: " + err.Error()}
+		if err := s.conn.notify("window/showMessage", msg); err != nil {
+			return nil, err
+		}
+	}
+	s.titlers[found] = titler
+
+	return titler, nil
+}
+
+func loadTitler(path string) (*titlecase.Titler, error) {
+	cfg := &config.Config{}
+	if path != "" {
+		var err error
+		if cfg, err = config.Load(path); err != nil {
+			return nil, err
+		}
+	}
+
+	opts, err := cfg.Options()
+	if err != nil {
+		return nil, err
+	}
+	return titlecase.New(opts), nil
+}
+
+func (f finding) diagnostic() diagnostic {
+	d := diagnostic{
+		Range:    f.rng,
+		Severity: severityWarning,
+		Code:     "title-case",
+		Source:   "gtl",
+		Message:  fmt.Sprintf("%q should be %q", f.heading.Text, f.expected),
+	}
+	if f.err != nil {
+		d.Severity = severityError
+		d.Code = ""
+		d.Message = f.err.Error()
+	}
+	return d
+}
+
+func headingRange(text string, heading document.Heading) textRange {
+	lineStart := strings.LastIndexByte(text[:heading.Start], '\n') + 1
+	start := utf16Len(text[lineStart:heading.Start])
+
+	return textRange{
+		Start: position{Line: heading.Line - 1, Character: start},
+		End:   position{Line: heading.Line - 1, Character: start + utf16Len(heading.Text)},
+	}
+}
+
+func utf16Len(text string) int {
+	n := 0
+	for _, r := range text {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+func formatFor(languageID, uri string) *document.Format {
+	format := document.FormatNamed(languageID)
+	if format == nil {
+		if u, err := url.Parse(uri); err == nil {
+			format = document.FormatFor(u.Path)
+		}
+	}
+	if format == document.TextFormat {
+		return nil
+	}
+	return format
+}
+
+func uriPath(uri string) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return "", false
+	}
+	return filepath.FromSlash(u.Path), true
+}
+
+func isConfig(uri string) bool {
+	u, err := url.Parse(uri)
+	return err == nil && path.Base(u.Path) == config.FileName
+}
+
+func wants(only []string, kind string) bool {
+	if len(only) == 0 {
+		return true
+	}
+	for _, o := range only {
+		if kind == o || strings.HasPrefix(kind, o+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func decode(params json.RawMessage, v any) error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
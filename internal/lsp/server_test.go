@@ -0,0 +1,211 @@
+package lsp
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+type client struct {
+	t    *testing.T
+	conn *conn
+	done chan error
+}
+
+func startServer(t *testing.T) *client {
+	t.Helper()
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+
+	c := &client{t: t, conn: newConn(outR, inW), done: make(chan error, 1)}
+	go func() {
+		err := Serve(inR, outW)
+		outW.Close()
+		c.done <- err
+	}()
+	return c
+}
+
+func (c *client) send(method string, id int, params any) {
+	c.t.Helper()
+	data, err := json.Marshal(params)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	msg := &message{Method: method, Params: data}
+	if id != 0 {
+		msg.ID = json.RawMessage(strconv.Itoa(id))
+	}
+	if err := c.conn.write(msg); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *client) receive(method string, v any) *message {
+	c.t.Helper()
+	msg, err := c.conn.read()
+	if err != nil {
+		c.t.Fatalf("reading %s: %v", method, err)
+	}
+	if msg.Method != method {
+		c.t.Fatalf("received %q, want %q", msg.Method, method)
+	}
+	data := msg.Params
+	if method == "" {
+		data = msg.Result
+	}
+	if v != nil {
+		if msg.Error != nil {
+			c.t.Fatalf("received error %d: %s", msg.Error.Code, msg.Error.Message)
+		}
+		if err := json.Unmarshal(data, v); err != nil {
+			c.t.Fatal(err)
+		}
+	}
+	return msg
+}
+
+func fileURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+func TestServer(t *testing.T) {
+	dir := t.TempDir()
+	uri := fileURI(filepath.Join(dir, "guide.md"))
+	text := "# the end\n\nbody text\n\n## 😀 a guide to life with cats\n\n## ???\n"
+
+	c := startServer(t)
+
+	c.send("textDocument/codeAction", 1, codeActionParams{})
+	if msg := c.receive("", nil); msg.Error == nil || msg.Error.Code != codeServerNotInitialized {
+		t.Errorf("request before initialize = %+v, want error %d", msg.Error, codeServerNotInitialized)
+	}
+
+	var init initializeResult
+	params := map[string]any{
+		"rootUri":      fileURI(dir),
+		"capabilities": map[string]any{"workspace": map[string]any{"didChangeWatchedFiles": map[string]any{"dynamicRegistration": true}}},
+	}
+	c.send("initialize", 2, params)
+	c.receive("", &init)
+	if init.Capabilities.TextDocumentSync.Change != syncFull {
+		t.Errorf("textDocumentSync.change = %d, want %d", init.Capabilities.TextDocumentSync.Change, syncFull)
+	}
+
+	c.send("initialized", 0, struct{}{})
+	var reg registrationParams
+	c.receive("client/registerCapability", &reg)
+	if len(reg.Registrations) != 1 || reg.Registrations[0].Method != "workspace/didChangeWatchedFiles" {
+		t.Errorf("registrations = %+v", reg.Registrations)
+	}
+
+	c.send("textDocument/didOpen", 0, map[string]any{
+		"textDocument": map[string]any{"uri": uri, "languageId": "markdown", "version": 1, "text": text},
+	})
+	var published publishDiagnosticsParams
+	c.receive("textDocument/publishDiagnostics", &published)
+	expected := []diagnostic{
+		{
+			Range:    textRange{Start: position{0, 2}, End: position{0, 9}},
+			Severity: severityWarning,
+			Code:     "title-case",
+			Source:   "gtl",
+			Message:  `"the end" should be "The End"`,
+		},
+		{
+			Range:    textRange{Start: position{4, 3}, End: position{4, 31}},
+			Severity: severityWarning,
+			Code:     "title-case",
+			Source:   "gtl",
+			Message:  `"😀 a guide to life with cats" should be "😀 A Guide to Life with Cats"`,
+		},
+	}
+	if !reflect.DeepEqual(published.Diagnostics, expected) {
+		t.Errorf("diagnostics = %+v, want %+v", published.Diagnostics, expected)
+	}
+
+	var actions []codeAction
+	c.send("textDocument/codeAction", 3, codeActionParams{
+		TextDocument: textDocumentIdentifier{URI: uri},
+		Range:        textRange{Start: position{0, 0}, End: position{0, 0}},
+	})
+	c.receive("", &actions)
+	if len(actions) != 2 {
+		t.Fatalf("got %d code actions, want 2", len(actions))
+	}
+	fix := actions[0]
+	if fix.Kind != kindQuickFix || fix.Title != `Change to "The End"` {
+		t.Errorf("quick fix = %q (%s)", fix.Title, fix.Kind)
+	}
+	wantEdit := []textEdit{{Range: expected[0].Range, NewText: "The End"}}
+	if !reflect.DeepEqual(fix.Edit.Changes[uri], wantEdit) {
+		t.Errorf("quick fix edit = %+v, want %+v", fix.Edit.Changes[uri], wantEdit)
+	}
+	if all := actions[1]; all.Kind != kindFixAll || len(all.Edit.Changes[uri]) != 2 {
+		t.Errorf("fix-all action = %+v", all)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".gtl.json"), []byte(`{"style": "ap"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c.send("workspace/didChangeWatchedFiles", 0, map[string]any{
+		"changes": []map[string]any{{"uri": fileURI(filepath.Join(dir, ".gtl.json")), "type": 1}},
+	})
+	c.receive("textDocument/publishDiagnostics", &published)
+	if len(published.Diagnostics) != 2 || published.Diagnostics[1].Message != `"😀 a guide to life with cats" should be "😀 A Guide to Life With Cats"` {
+		t.Errorf("diagnostics after reload = %+v", published.Diagnostics)
+	}
+
+	c.send("textDocument/didChange", 0, map[string]any{
+		"textDocument":   map[string]any{"uri": uri, "version": 2},
+		"contentChanges": []map[string]any{{"text": "# The End\n"}},
+	})
+	c.receive("textDocument/publishDiagnostics", &published)
+	if published.Version != 2 || len(published.Diagnostics) != 0 {
+		t.Errorf("diagnostics after change = %+v", published)
+	}
+
+	c.send("textDocument/didClose", 0, map[string]any{"textDocument": map[string]any{"uri": uri}})
+	c.receive("textDocument/publishDiagnostics", &published)
+
+	c.send("shutdown", 4, nil)
+	if msg := c.receive("", nil); string(msg.Result) != "null" {
+		t.Errorf("shutdown result = %s, want null", msg.Result)
+	}
+	c.send("exit", 0, nil)
+	if err := <-c.done; err != nil {
+		t.Errorf("Serve returned unexpected error: %v", err)
+	}
+}
+
+func TestFormatFor(t *testing.T) {
+	tests := []struct {
+		languageID string
+		uri        string
+		expected   string
+	}{
+		{"markdown", "file:///docs/readme", "markdown"},
+		{"asciidoc", "untitled:Untitled-1", "asciidoc"},
+		{"restructuredtext", "file:///docs/index.rst", "restructuredtext"},
+		{"plaintext", "file:///docs/guide.rst", "restructuredtext"},
+		{"plaintext", "file:///docs/notes.txt", ""},
+		{"go", "file:///main.go", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.languageID+" "+tt.uri, func(t *testing.T) {
+			name := ""
+			if format := formatFor(tt.languageID, tt.uri); format != nil {
+				name = format.Name
+			}
+			if name != tt.expected {
+				t.Errorf("formatFor(%q, %q) = %q, want %q", tt.languageID, tt.uri, name, tt.expected)
+			}
+		})
+	}
+}
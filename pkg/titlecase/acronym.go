@@ -0,0 +1,150 @@
+package titlecase
+
+import (
+	"strings"
+	"unicode"
+)
+
+var builtinAcronyms = []string{
+	// Organizations and places
+	"BBC", "CIA", "EU", "FAQ", "FBI", "IMF", "NASA", "NASDAQ", "NATO", "NHS",
+	"OPEC", "UK", "UNESCO", "UNICEF", "USA",
+
+	// Business
+	"B2B", "B2C", "CEO", "CFO", "CTO", "HR", "KPI", "PR", "ROI", "SEO",
+
+	// Health and science
+	"COVID", "DNA", "HIV", "RNA",
+
+	// Computing
+	"AI", "API", "ASCII", "AWS", "CI", "CLI", "CPU", "CSS", "CSV", "DNS", "GCP",
+	"GPT", "GPU", "GUI", "HTML", "HTTP", "HTTPS", "IDE", "JSON", "JWT", "LLM",
+	"ML", "PDF", "PHP", "RAM", "SDK", "SQL", "SSD", "SSH", "SSL", "TCP",
+	"TLS", "UDP", "UI", "URL", "USB", "UTF", "UX", "VPN", "XML", "YAML",
+
+	// Media
+	"3D", "4K", "DVD", "HD", "MP3", "MP4", "TV",
+}
+
+// isAcronym reports whether word is a known acronym, the plural or
+// possessive of an acronym ("APIs", "CEO's"), or an uppercase alphanumeric
+// code such as "MP3" or "B2B".
+func (t *Titler) isAcronym(word string) bool {
+	if t.acronyms[word] {
+		return true
+	}
+
+	if base, ok := trimAcronymSuffix(word); ok {
+		return t.acronyms[base] || isUpperWord(base, 2, 6)
+	}
+
+	hasDigit := false
+	hasLetter := false
+	for _, r := range word {
+		switch {
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsLetter(r):
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			hasLetter = true
+		default:
+			return false
+		}
+	}
+
+	return hasDigit && hasLetter
+}
+
+func trimAcronymSuffix(word string) (string, bool) {
+	base, suffix := splitPossessive(word)
+	if suffix == "" {
+		base, _ = strings.CutSuffix(word, "s")
+	}
+	if base != word && isUpperWord(base, 2, -1) {
+		return base, true
+	}
+	return "", false
+}
+
+// acronymCasing returns an acronym as written, with the "'s" of a
+// possessive lowercased as in "UNESCO's".
+func acronymCasing(word string) string {
+	if base, suffix := splitPossessive(word); suffix != "" {
+		return base + strings.ToLower(suffix)
+	}
+	return word
+}
+
+func isUpperWord(word string, minLetters, maxLetters int) bool {
+	letters := 0
+	for _, r := range word {
+		if !unicode.IsLetter(r) || !unicode.IsUpper(r) {
+			return false
+		}
+		letters++
+	}
+	return letters >= minLetters && (maxLetters < 0 || letters <= maxLetters)
+}
+
+// isShouting reports whether every letter of a title with at least two
+// words is uppercase, as in "THE QUICK BROWN FOX".
+func isShouting(tokens []Token) bool {
+	words := 0
+
+	for _, token := range tokens {
+		if !token.IsWord || token.Protected {
+			continue
+		}
+
+		hasLetter := false
+		for _, r := range token.Text {
+			if !unicode.IsLetter(r) {
+				continue
+			}
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			hasLetter = true
+		}
+		if hasLetter {
+			words++
+		}
+	}
+
+	return words >= 2
+}
+
+// normalizeShouting lowercases the words of an all-caps title except for
+// known acronyms, so that they are cased like any other input.
+func (t *Titler) normalizeShouting(tokens []Token) []Token {
+	normalized := make([]Token, len(tokens))
+	copy(normalized, tokens)
+
+	for i, token := range normalized {
+		if !token.IsWord || token.Protected {
+			continue
+		}
+
+		parts := strings.Split(token.Text, "-")
+		for j, part := range parts {
+			parts[j] = t.normalizeShoutedWord(part)
+		}
+		normalized[i].Text = strings.Join(parts, "-")
+	}
+
+	return normalized
+}
+
+func (t *Titler) normalizeShoutedWord(word string) string {
+	if t.acronyms[word] {
+		return word
+	}
+
+	if base, suffix := splitPossessive(word); suffix != "" && t.acronyms[base] {
+		return base + strings.ToLower(suffix)
+	}
+
+	return strings.ToLower(word)
+}
@@ -0,0 +1,136 @@
+package titlecase
+
+import (
+	"testing"
+)
+
+func TestAcronyms(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		input    string
+		expected string
+	}{
+		{
+			name:     "plural acronym",
+			input:    "designing good APIs",
+			expected: "Designing Good APIs",
+		},
+		{
+			name:     "possessive acronym",
+			input:    "the CEO's guide to SDKs",
+			expected: "The CEO's Guide to SDKs",
+		},
+		{
+			name:     "long known acronym",
+			input:    "investing on the NASDAQ",
+			expected: "Investing on the NASDAQ",
+		},
+		{
+			name:     "hyphenated acronyms",
+			input:    "using the AWS-SDK",
+			expected: "Using the AWS-SDK",
+		},
+		{
+			name:     "shouting normalized",
+			input:    "THE QUICK BROWN FOX",
+			expected: "The Quick Brown Fox",
+		},
+		{
+			name:     "shouting keeps known acronyms",
+			input:    "HOW THE FBI USES AI",
+			expected: "How the FBI Uses AI",
+		},
+		{
+			name:     "shouting possessive",
+			input:    "UNESCO'S WORLD HERITAGE LIST",
+			expected: "UNESCO's World Heritage List",
+		},
+		{
+			name:     "uppercase possessive",
+			input:    "UNESCO'S world heritage",
+			expected: "UNESCO's World Heritage",
+		},
+		{
+			name:     "shouting hyphenated",
+			input:    "COVID-RELATED TRAVEL RULES",
+			expected: "COVID-Related Travel Rules",
+		},
+		{
+			name:     "shouting dictionary words",
+			input:    "GETTING STARTED WITH GITHUB",
+			expected: "Getting Started with GitHub",
+		},
+		{
+			name:     "shouting custom acronym",
+			opts:     Options{Acronyms: []string{"gtl"}},
+			input:    "WHY GTL EXISTS",
+			expected: "Why GTL Exists",
+		},
+		{
+			name:     "single shouted word kept",
+			input:    "IMPORTANT",
+			expected: "Important",
+		},
+		{
+			name:     "acronyms only",
+			input:    "API SDK",
+			expected: "API SDK",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := New(tt.opts).Title(tt.input)
+			if err != nil {
+				t.Errorf("Title(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("Title(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsAcronym(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected bool
+	}{
+		{word: "API", expected: true},
+		{word: "NASDAQ", expected: true},
+		{word: "APIs", expected: true},
+		{word: "CEO's", expected: true},
+		{word: "UNESCO'S", expected: true},
+		{word: "MP3", expected: true},
+		{word: "B2B", expected: true},
+		{word: "4K", expected: true},
+		{word: "Is", expected: false},
+		{word: "api", expected: false},
+		{word: "mp3", expected: false},
+		{word: "Apis", expected: false},
+		{word: "VERYLONGWORD", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if result := defaultTitler.isAcronym(tt.word); result != tt.expected {
+				t.Errorf("isAcronym(%q) = %t, want %t", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSentenceShouting(t *testing.T) {
+	input := "HOW THE FBI USES AI"
+	expected := "How the FBI uses AI"
+
+	result, err := ToSentenceCase(input)
+	if err != nil {
+		t.Fatalf("ToSentenceCase(%q) returned unexpected error: %v", input, err)
+	}
+	if result != expected {
+		t.Errorf("ToSentenceCase(%q) = %q, want %q", input, result, expected)
+	}
+}
@@ -0,0 +1,88 @@
+package titlecase
+
+import (
+	"strings"
+)
+
+// abbreviations lists words that end in a period without ending a sentence,
+// as in "Mr. Smith" or "Apple vs. Microsoft".
+var abbreviations = wordSet(
+	"mr", "mrs", "ms", "dr", "prof", "st", "mt", "ft", "jr", "sr", "gen",
+	"col", "lt", "sgt", "capt", "gov", "sen", "rep", "rev", "vs", "etc",
+	"approx", "vol", "no", "fig", "inc", "ltd", "co", "corp",
+)
+
+// hasBoundary reports whether punctuation contains one of marks. An ellipsis
+// is never a boundary, and a standalone run of hyphens or an en dash counts
+// as the em dash it stands in for.
+func hasBoundary(punctuation, marks string) bool {
+	punctuation = strings.ReplaceAll(punctuation, "...", "")
+	punctuation = strings.ReplaceAll(punctuation, "…", "")
+
+	if strings.ContainsRune(marks, '—') &&
+		(punctuation == "–" || punctuation != "" && strings.Trim(punctuation, "-") == "") {
+		return true
+	}
+	return strings.ContainsAny(punctuation, marks)
+}
+
+// sentenceBoundary reports whether punctuation ends a sentence or starts a
+// subtitle, the only places where Sentence capitalizes a word.
+func sentenceBoundary(punctuation string) bool {
+	return hasBoundary(punctuation, ":.?!")
+}
+
+// isBoundary reports whether the punctuation token at index separates a
+// title from a subtitle. A period after an abbreviation or an initial, as in
+// "Dr. Strangelove" or "J. R. R. Tolkien", is not a boundary.
+func (t *Titler) isBoundary(tokens []Token, index int) bool {
+	return isBoundaryOf(tokens, index, t.style.Boundary)
+}
+
+func isBoundaryOf(tokens []Token, index int, boundary func(string) bool) bool {
+	punctuation := strings.TrimSpace(tokens[index].Text)
+	if !boundary(punctuation) {
+		return false
+	}
+
+	if strings.HasPrefix(punctuation, ".") && index > 0 && tokens[index-1].IsWord {
+		word := strings.ToLower(tokens[index-1].Text)
+		if len([]rune(word)) == 1 || abbreviations[word] {
+			return boundary(strings.TrimPrefix(punctuation, "."))
+		}
+	}
+
+	return true
+}
+
+// followsBoundary reports whether a boundary comes between the word at
+// index and the previous word.
+func (t *Titler) followsBoundary(tokens []Token, index int) bool {
+	return followsBoundaryOf(tokens, index, t.style.Boundary)
+}
+
+func followsBoundaryOf(tokens []Token, index int, boundary func(string) bool) bool {
+	for i := index - 1; i >= 0; i-- {
+		if tokens[i].IsWord {
+			return false
+		}
+		if tokens[i].IsPunctuation && isBoundaryOf(tokens, i, boundary) {
+			return true
+		}
+	}
+	return false
+}
+
+// precedesBoundary reports whether the word at index is directly followed
+// by a boundary, which makes it the last word of a title or subtitle.
+func (t *Titler) precedesBoundary(tokens []Token, index int) bool {
+	for i := index + 1; i < len(tokens); i++ {
+		if tokens[i].IsWord {
+			return false
+		}
+		if tokens[i].IsPunctuation {
+			return t.isBoundary(tokens, i)
+		}
+	}
+	return false
+}
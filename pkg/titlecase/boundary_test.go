@@ -0,0 +1,128 @@
+package titlecase
+
+import (
+	"testing"
+)
+
+func TestSubtitleBoundaries(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    Style
+		input    string
+		expected string
+	}{
+		{
+			name:     "em dash",
+			input:    "faith — a journey",
+			expected: "Faith — A Journey",
+		},
+		{
+			name:     "unspaced em dash",
+			input:    "faith—a journey",
+			expected: "Faith—A Journey",
+		},
+		{
+			name:     "double hyphen",
+			input:    "faith -- a journey",
+			expected: "Faith -- A Journey",
+		},
+		{
+			name:     "spaced hyphen",
+			input:    "faith - a journey",
+			expected: "Faith - A Journey",
+		},
+		{
+			name:     "question mark",
+			input:    "why? a story",
+			expected: "Why? A Story",
+		},
+		{
+			name:     "exclamation mark",
+			input:    "stop! the musical",
+			expected: "Stop! The Musical",
+		},
+		{
+			name:     "semicolon",
+			input:    "the end; a new beginning",
+			expected: "The End; A New Beginning",
+		},
+		{
+			name:     "period between sentences",
+			input:    "it works. on my machine",
+			expected: "It Works. On My Machine",
+		},
+		{
+			name:     "word before boundary is last",
+			input:    "what are you looking for? a guide",
+			expected: "What Are You Looking For? A Guide",
+		},
+		{
+			name:     "word before colon is last",
+			input:    "a place to come from: notes",
+			expected: "A Place to Come From: Notes",
+		},
+		{
+			name:     "abbreviation",
+			input:    "mr. smith goes to washington",
+			expected: "Mr. Smith Goes to Washington",
+		},
+		{
+			name:     "abbreviation before small word",
+			input:    "cats vs. the world",
+			expected: "Cats Vs. the World",
+		},
+		{
+			name:     "initials",
+			input:    "j. r. r. tolkien and the hobbit",
+			expected: "J. R. R. Tolkien and the Hobbit",
+		},
+		{
+			name:     "ellipsis",
+			input:    "wait... and see",
+			expected: "Wait... and See",
+		},
+		{
+			name:     "hyphenated word is not a dash",
+			input:    "test--case of a kind",
+			expected: "Test--Case of a Kind",
+		},
+		{
+			name:     "mla dash is not a boundary",
+			style:    MLA,
+			input:    "faith — a journey",
+			expected: "Faith — a Journey",
+		},
+		{
+			name:     "apa semicolon is not a boundary",
+			style:    APA,
+			input:    "the end; a new beginning",
+			expected: "The End; a New Beginning",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := New(Options{Style: tt.style}).Title(tt.input)
+			if err != nil {
+				t.Errorf("Title(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("Title(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSentenceBoundaries(t *testing.T) {
+	input := "Why? A Story Of Faith — And Doubt; First Part"
+	expected := "Why? A story of faith — and doubt; first part"
+
+	result, err := ToSentenceCase(input)
+	if err != nil {
+		t.Fatalf("ToSentenceCase(%q) returned unexpected error: %v", input, err)
+	}
+	if result != expected {
+		t.Errorf("ToSentenceCase(%q) = %q, want %q", input, result, expected)
+	}
+}
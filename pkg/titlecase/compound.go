@@ -0,0 +1,43 @@
+package titlecase
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// prefixes lists prefixes and combining forms that cannot stand alone as
+// words. "Self" is a word in its own right, so "Self-Driving" keeps both
+// capitals.
+var prefixes = wordSet(
+	"anti", "bi", "co", "counter", "de", "extra", "hyper", "infra", "inter",
+	"intra", "macro", "meta", "micro", "mid", "mini", "multi", "neo", "non",
+	"post", "pre", "pro", "pseudo", "re", "semi", "sub", "super", "trans",
+	"tri", "ultra", "un",
+)
+
+var numberWords = wordSet(
+	"one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+	"twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty",
+	"ninety",
+	"first", "second", "third", "fourth", "fifth", "sixth", "seventh",
+	"eighth", "ninth",
+	"half", "halves", "thirds", "quarter", "quarters", "fourths", "fifths",
+	"sixths", "sevenths", "eighths", "ninths", "tenth", "tenths",
+)
+
+// isPrefix reports whether the lowercase compound element part is a prefix
+// or a single letter, as in "anti-inflammatory" or "e-mail".
+func isPrefix(part string) bool {
+	if prefixes[part] {
+		return true
+	}
+	r, size := utf8.DecodeRuneInString(part)
+	return size == len(part) && unicode.IsLetter(r)
+}
+
+// isNumberWord reports whether the lowercase compound element part belongs
+// to a spelled-out number or fraction, such as "twenty-first" or
+// "two-thirds".
+func isNumberWord(part string) bool {
+	return numberWords[part]
+}
@@ -0,0 +1,95 @@
+package titlecase
+
+import (
+	"testing"
+)
+
+func TestHyphenatedCompounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    Style
+		input    string
+		expected string
+	}{
+		{
+			name:     "prefix",
+			input:    "anti-inflammatory drugs",
+			expected: "Anti-inflammatory Drugs",
+		},
+		{
+			name:     "prefix at end",
+			input:    "how to re-enter",
+			expected: "How to Re-enter",
+		},
+		{
+			name:     "non prefix",
+			input:    "the non-profit sector",
+			expected: "The Non-profit Sector",
+		},
+		{
+			name:     "self is a word",
+			input:    "self-driving cars",
+			expected: "Self-Driving Cars",
+		},
+		{
+			name:     "single letter prefix",
+			input:    "e-mail etiquette",
+			expected: "E-mail Etiquette",
+		},
+		{
+			name:     "single letter prefix mid title",
+			input:    "reading x-ray images",
+			expected: "Reading X-ray Images",
+		},
+		{
+			name:     "spelled-out ordinal",
+			input:    "the twenty-first century",
+			expected: "The Twenty-First Century",
+		},
+		{
+			name:     "spelled-out fraction",
+			input:    "a two-thirds majority",
+			expected: "A Two-Thirds Majority",
+		},
+		{
+			name:     "proper noun from dictionary",
+			input:    "pre-javascript web pages",
+			expected: "Pre-JavaScript Web Pages",
+		},
+		{
+			name:     "proper adjective after prefix",
+			input:    "anti-American sentiment and pre-Columbian art",
+			expected: "Anti-American Sentiment and Pre-Columbian Art",
+		},
+		{
+			name:     "ordinary compound",
+			input:    "a long-term plan",
+			expected: "A Long-Term Plan",
+		},
+		{
+			name:     "ap capitalizes after prefix",
+			style:    AP,
+			input:    "anti-inflammatory drugs",
+			expected: "Anti-Inflammatory Drugs",
+		},
+		{
+			name:     "apa capitalizes after prefix",
+			style:    APA,
+			input:    "a non-profit guide",
+			expected: "A Non-Profit Guide",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := New(Options{Style: tt.style}).Title(tt.input)
+			if err != nil {
+				t.Errorf("Title(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("Title(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
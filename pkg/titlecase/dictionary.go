@@ -0,0 +1,96 @@
+package titlecase
+
+import (
+	"sort"
+	"strings"
+)
+
+var builtinWords = []string{
+	// Companies and brands
+	"AirPods", "AirTag", "eBay", "FedEx", "iCloud", "iMac", "iPad", "iPhone",
+	"iPod", "iTunes", "LinkedIn", "MacBook", "McDonald", "PayPal", "PlayStation",
+	"SoundCloud", "TikTok", "WhatsApp", "YouTube",
+
+	// Operating systems and platforms
+	"iOS", "iPadOS", "macOS", "tvOS", "watchOS", "visionOS", "FreeBSD",
+	"OpenBSD", "NetBSD", "GitHub", "GitLab", "WordPress",
+	"DigitalOcean",
+
+	// Languages and runtimes
+	"JavaScript", "TypeScript", "CoffeeScript", "PowerShell", "WebAssembly",
+	"LaTeX", "TeX", "OCaml", "PureScript", "MicroPython",
+
+	// Databases
+	"PostgreSQL", "MySQL", "SQLite", "MariaDB", "MongoDB", "DynamoDB", "CouchDB",
+	"InfluxDB", "CockroachDB", "NoSQL", "GraphQL", "ClickHouse",
+
+	// Tools, libraries and technologies
+	"DevOps", "DevTools", "gRPC", "IntelliJ", "jQuery", "npm", "pnpm", "NumPy",
+	"OAuth", "OpenAI", "OpenID", "OpenSSL", "OpenStack", "PyPI", "PyTorch", "SaaS",
+	"SciPy", "TensorFlow", "WebGL", "WebGPU", "WebRTC", "WebSocket", "WebSockets",
+	"Xcode",
+
+	// Numeronyms and architectures
+	"a11y", "i18n", "k8s", "l10n", "x64", "x86",
+}
+
+// Dictionary maps words to their canonical spelling, such as "iPhone" or
+// "JavaScript". Lookups ignore case. A Dictionary must not be modified while
+// a Titler is being created from it.
+type Dictionary struct {
+	words map[string]string
+}
+
+// NewDictionary returns a Dictionary holding words.
+func NewDictionary(words ...string) *Dictionary {
+	d := &Dictionary{words: make(map[string]string, len(words))}
+	d.Add(words...)
+	return d
+}
+
+// DefaultDictionary returns a new Dictionary holding the built-in spellings
+// of common brands, products, languages and technologies.
+func DefaultDictionary() *Dictionary {
+	return NewDictionary(builtinWords...)
+}
+
+// Add registers words, replacing any existing spelling of the same word.
+func (d *Dictionary) Add(words ...string) {
+	for _, word := range words {
+		if word != "" {
+			d.words[strings.ToLower(word)] = word
+		}
+	}
+}
+
+// Lookup returns the canonical spelling of word.
+func (d *Dictionary) Lookup(word string) (string, bool) {
+	canonical, ok := d.words[strings.ToLower(word)]
+	return canonical, ok
+}
+
+// Words returns the canonical spellings in the Dictionary, sorted.
+func (d *Dictionary) Words() []string {
+	words := make([]string, 0, len(d.words))
+	for _, word := range d.words {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	return words
+}
+
+func (t *Titler) lookupWord(word string) (string, bool) {
+	if canonical, ok := t.dictionary.Lookup(word); ok {
+		return canonical, true
+	}
+
+	base, suffix := splitPossessive(word)
+	if suffix == "" {
+		return "", false
+	}
+	if canonical, ok := t.dictionary.Lookup(base); ok {
+		return canonical + strings.ToLower(suffix), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,117 @@
+package titlecase
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDefaultDictionary(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "brands",
+			input:    "buying an iphone on ebay",
+			expected: "Buying an iPhone on eBay",
+		},
+		{
+			name:     "languages",
+			input:    "JAVASCRIPT and typescript",
+			expected: "JavaScript and TypeScript",
+		},
+		{
+			name:     "lowercase brand at start",
+			input:    "npm tips and tricks",
+			expected: "npm Tips and Tricks",
+		},
+		{
+			name:     "possessive",
+			input:    "inside github's data centers",
+			expected: "Inside GitHub's Data Centers",
+		},
+		{
+			name:     "hyphenated part",
+			input:    "a youtube-style player",
+			expected: "A YouTube-Style Player",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ToTitleCase(tt.input)
+			if err != nil {
+				t.Errorf("ToTitleCase(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("ToTitleCase(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDictionaryOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		input    string
+		expected string
+	}{
+		{
+			name:     "empty dictionary disables built-ins",
+			opts:     Options{Dictionary: NewDictionary()},
+			input:    "using javascript",
+			expected: "Using Javascript",
+		},
+		{
+			name:     "custom dictionary",
+			opts:     Options{Dictionary: NewDictionary("AcmeCloud")},
+			input:    "welcome to acmecloud with javascript",
+			expected: "Welcome to AcmeCloud with Javascript",
+		},
+		{
+			name:     "words extend the default dictionary",
+			opts:     Options{Words: []string{"AcmeCloud"}},
+			input:    "acmecloud loves javascript",
+			expected: "AcmeCloud Loves JavaScript",
+		},
+		{
+			name:     "words override built-in spellings",
+			opts:     Options{Words: []string{"Javascript"}},
+			input:    "learning javascript",
+			expected: "Learning Javascript",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := New(tt.opts).Title(tt.input)
+			if err != nil {
+				t.Errorf("Title(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("Title(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDictionary(t *testing.T) {
+	d := NewDictionary("GitHub", "macOS")
+	d.Add("gRPC", "")
+
+	if word, ok := d.Lookup("GITHUB"); !ok || word != "GitHub" {
+		t.Errorf("Lookup(%q) = %q, %t, want %q, true", "GITHUB", word, ok, "GitHub")
+	}
+	if word, ok := d.Lookup("linux"); ok {
+		t.Errorf("Lookup(%q) = %q, true, want not found", "linux", word)
+	}
+
+	expected := []string{"GitHub", "gRPC", "macOS"}
+	if words := d.Words(); !reflect.DeepEqual(words, expected) {
+		t.Errorf("Words() = %q, want %q", words, expected)
+	}
+}
@@ -0,0 +1,151 @@
+package titlecase
+
+// Rule identifies the rule that decided the casing of a token.
+type Rule int
+
+const (
+	// RuleUnchanged applies to whitespace and punctuation, which are never
+	// changed.
+	RuleUnchanged Rule = iota
+	// RuleProtected applies to URLs, paths, code spans, placeholders and
+	// escaped text.
+	RuleProtected
+	// RuleDictionary applies to words with a fixed spelling in the
+	// Dictionary.
+	RuleDictionary
+	// RuleNumber applies to ordinals, units and alphanumeric codes.
+	RuleNumber
+	// RuleAcronym applies to acronyms kept in capitals.
+	RuleAcronym
+	// RuleMixedCase applies to words with internal capitals kept as written.
+	RuleMixedCase
+	// RuleSmallWord applies to small words kept lowercase by the Style or
+	// Options.SmallWords.
+	RuleSmallWord
+	// RuleCompound applies to compound elements lowercased after a prefix,
+	// as "inflammatory" in "Anti-inflammatory".
+	RuleCompound
+	// RuleFirstWord applies to small words capitalized because they start
+	// the title.
+	RuleFirstWord
+	// RuleLastWord applies to small words capitalized because they end the
+	// title.
+	RuleLastWord
+	// RuleAfterPunctuation applies to small words capitalized because they
+	// follow a colon, another subtitle boundary or an opening quote.
+	RuleAfterPunctuation
+	// RuleBeforePunctuation applies to small words capitalized because they
+	// end a title before a subtitle boundary.
+	RuleBeforePunctuation
+	// RuleParticle applies to small words capitalized because they act as
+	// adverbs or phrasal-verb particles.
+	RuleParticle
+	// RuleCapitalized applies to every other word.
+	RuleCapitalized
+	// RuleHyphenated applies to hyphenated compounds, whose elements are
+	// described by Decision.Parts.
+	RuleHyphenated
+)
+
+var ruleNames = [...]string{
+	RuleUnchanged:         "unchanged",
+	RuleProtected:         "protected",
+	RuleDictionary:        "dictionary",
+	RuleNumber:            "number",
+	RuleAcronym:           "acronym",
+	RuleMixedCase:         "mixed-case",
+	RuleSmallWord:         "small-word",
+	RuleCompound:          "compound",
+	RuleFirstWord:         "first-word",
+	RuleLastWord:          "last-word",
+	RuleAfterPunctuation:  "after-punctuation",
+	RuleBeforePunctuation: "before-punctuation",
+	RuleParticle:          "particle",
+	RuleCapitalized:       "capitalized",
+	RuleHyphenated:        "hyphenated",
+}
+
+// String returns the name of the rule, such as "small-word".
+func (r Rule) String() string {
+	if r < 0 || int(r) >= len(ruleNames) {
+		return "unknown"
+	}
+	return ruleNames[r]
+}
+
+// Decision describes how one token of a title was cased.
+type Decision struct {
+	// Token is the token as it appears in the input.
+	Token Token
+	// Output is the text written for the token.
+	Output string
+	// Rule is the rule that decided Output.
+	Rule Rule
+	// Position is where the word appears in the title. It is zero for
+	// tokens that are not words.
+	Position Position
+	// Parts describes each element of a hyphenated compound.
+	Parts []Decision
+}
+
+// Explain returns the decisions made when converting text with the default
+// Options.
+func Explain(text string) ([]Decision, error) {
+	return defaultTitler.Explain(text)
+}
+
+// Explain returns one Decision for every token of text, in input order,
+// describing how Title cases it. Joining the Output of every Decision gives
+// the result of Title. It returns the same errors as Title.
+func (t *Titler) Explain(text string) ([]Decision, error) {
+	tokens, err := t.tokenizeInput(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.decide(tokens)
+}
+
+func wordDecision(word, output string, rule Rule, pos Position) Decision {
+	return Decision{
+		Token:    Token{Text: word, IsWord: true},
+		Output:   output,
+		Rule:     rule,
+		Position: pos,
+	}
+}
+
+// lowercaseRule tells whether word was lowercased as a small word or because
+// it follows a prefix in a compound.
+func (t *Titler) lowercaseRule(word string, pos Position) Rule {
+	if pos.Prefix != "" {
+		plain := pos
+		plain.Prefix = ""
+		if !t.isLowercase(word, plain) {
+			return RuleCompound
+		}
+	}
+	return RuleSmallWord
+}
+
+// capitalizeRule tells which rule kept a capitalized word from being
+// lowercased, if any.
+func (t *Titler) capitalizeRule(word string, pos Position) Rule {
+	plain := pos
+	plain.First, plain.Last = false, false
+	if pos.Role == RoleParticle {
+		plain.Role = RolePreposition
+	}
+	if !t.isLowercase(word, plain) {
+		return RuleCapitalized
+	}
+
+	switch {
+	case pos.First:
+		return RuleFirstWord
+	case pos.Last:
+		return RuleLastWord
+	default:
+		return RuleParticle
+	}
+}
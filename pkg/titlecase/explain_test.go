@@ -0,0 +1,103 @@
+package titlecase
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  Options
+		input string
+		word  string
+		rule  Rule
+	}{
+		{name: "first word", input: "the end", word: "the", rule: RuleFirstWord},
+		{name: "last word", input: "what dreams are made of", word: "of", rule: RuleLastWord},
+		{name: "small word", input: "lord of the rings", word: "of", rule: RuleSmallWord},
+		{name: "capitalized", input: "lord of the rings", word: "rings", rule: RuleCapitalized},
+		{name: "after colon", input: "notes: a memoir", word: "a", rule: RuleAfterPunctuation},
+		{name: "before boundary", input: "looking for? a guide", word: "for", rule: RuleBeforePunctuation},
+		{name: "after quote", input: `he said "the end" twice`, word: "the", rule: RuleAfterPunctuation},
+		{name: "acronym", input: "the NASA story", word: "NASA", rule: RuleAcronym},
+		{name: "mixed case", input: "using useEffect well", word: "useEffect", rule: RuleMixedCase},
+		{name: "dictionary", input: "hosting on github", word: "github", rule: RuleDictionary},
+		{name: "number", input: "the 21st century", word: "21st", rule: RuleNumber},
+		{name: "protected", input: "moving to example.com", word: "example.com", rule: RuleProtected},
+		{name: "particle", input: "set up your account", word: "up", rule: RuleParticle},
+		{name: "hyphenated", input: "self-driving cars", word: "self-driving", rule: RuleHyphenated},
+		{name: "small word option", opts: Options{SmallWords: []string{"vs"}}, input: "cats vs dogs", word: "vs", rule: RuleSmallWord},
+		{name: "punctuation", input: "hello, world", word: ",", rule: RuleUnchanged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decisions, err := New(tt.opts).Explain(tt.input)
+			if err != nil {
+				t.Fatalf("Explain(%q) returned unexpected error: %v", tt.input, err)
+			}
+
+			found := false
+			for _, d := range decisions {
+				if d.Token.Text != tt.word {
+					continue
+				}
+				found = true
+				if d.Rule != tt.rule {
+					t.Errorf("Explain(%q)[%q].Rule = %s, want %s", tt.input, tt.word, d.Rule, tt.rule)
+				}
+			}
+			if !found {
+				t.Errorf("Explain(%q) has no decision for %q", tt.input, tt.word)
+			}
+		})
+	}
+}
+
+func TestExplainMatchesTitle(t *testing.T) {
+	inputs := []string{
+		"the co-founder of a start-up",
+		"WHY THE FBI USES AI",
+		"configuring `max_conns` on example.com: a guide",
+		"anti-inflammatory drugs for the twenty-first century",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			decisions, err := Explain(input)
+			if err != nil {
+				t.Fatalf("Explain(%q) returned unexpected error: %v", input, err)
+			}
+			title, err := ToTitleCase(input)
+			if err != nil {
+				t.Fatalf("ToTitleCase(%q) returned unexpected error: %v", input, err)
+			}
+
+			var output, original strings.Builder
+			for _, d := range decisions {
+				output.WriteString(d.Output)
+				original.WriteString(d.Token.Text)
+			}
+			if output.String() != title {
+				t.Errorf("Explain(%q) output = %q, want %q", input, output.String(), title)
+			}
+			if original.String() != input {
+				t.Errorf("Explain(%q) tokens = %q, want original text", input, original.String())
+			}
+		})
+	}
+}
+
+func TestExplainCompoundParts(t *testing.T) {
+	decisions, err := Explain("anti-inflammatory")
+	if err != nil {
+		t.Fatalf("Explain returned unexpected error: %v", err)
+	}
+	if len(decisions) != 1 || len(decisions[0].Parts) != 2 {
+		t.Fatalf("Explain returned %+v, want one decision with two parts", decisions)
+	}
+	if rule := decisions[0].Parts[1].Rule; rule != RuleCompound {
+		t.Errorf("Parts[1].Rule = %s, want %s", rule, RuleCompound)
+	}
+}
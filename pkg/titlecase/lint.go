@@ -0,0 +1,56 @@
+package titlecase
+
+import (
+	"unicode/utf8"
+)
+
+// Violation describes a word whose casing differs from the title-cased form.
+type Violation struct {
+	// Word is the word as it appears in the input.
+	Word string
+	// Expected is the correctly cased word.
+	Expected string
+	// Offset is the byte offset of Word in the input.
+	Offset int
+	// Column is the 1-based position of Word in the input, counted in runes.
+	Column int
+}
+
+// Lint reports the words of text that are not title-cased using the default
+// Options.
+func Lint(text string) ([]Violation, error) {
+	return defaultTitler.Lint(text)
+}
+
+// Lint reports every word of text whose casing differs from the result of
+// Title, in input order. It returns the same errors as Title.
+func (t *Titler) Lint(text string) ([]Violation, error) {
+	tokens, err := t.tokenizeInput(text)
+	if err != nil {
+		return nil, err
+	}
+
+	titled, err := t.titleTokens(tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []Violation
+	offset := 0
+	column := 1
+
+	for i, token := range tokens {
+		if token.IsWord && !token.Protected && titled[i] != token.Text {
+			violations = append(violations, Violation{
+				Word:     token.Text,
+				Expected: titled[i],
+				Offset:   offset,
+				Column:   column,
+			})
+		}
+		offset += len(token.Text)
+		column += utf8.RuneCountInString(token.Text)
+	}
+
+	return violations, nil
+}
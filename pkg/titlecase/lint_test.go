@@ -0,0 +1,64 @@
+package titlecase
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Violation
+	}{
+		{
+			name:     "correct title",
+			input:    "The Lord of the Rings",
+			expected: nil,
+		},
+		{
+			name:  "lowercase words",
+			input: "the Lord Of the rings",
+			expected: []Violation{
+				{Word: "the", Expected: "The", Offset: 0, Column: 1},
+				{Word: "Of", Expected: "of", Offset: 9, Column: 10},
+				{Word: "rings", Expected: "Rings", Offset: 16, Column: 17},
+			},
+		},
+		{
+			name:  "columns count runes",
+			input: "Ñoño über alles",
+			expected: []Violation{
+				{Word: "über", Expected: "Über", Offset: 7, Column: 6},
+				{Word: "alles", Expected: "Alles", Offset: 13, Column: 11},
+			},
+		},
+		{
+			name:  "hyphenated word",
+			input: "A State-Of-The-Art Design",
+			expected: []Violation{
+				{Word: "State-Of-The-Art", Expected: "State-of-the-Art", Offset: 2, Column: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := Lint(tt.input)
+			if err != nil {
+				t.Errorf("Lint(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if !reflect.DeepEqual(violations, tt.expected) {
+				t.Errorf("Lint(%q) = %+v, want %+v", tt.input, violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLintErrors(t *testing.T) {
+	if _, err := Lint(""); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("Lint(%q) error = %v, want %v", "", err, ErrEmptyInput)
+	}
+}
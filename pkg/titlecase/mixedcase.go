@@ -0,0 +1,74 @@
+package titlecase
+
+import (
+	"unicode"
+)
+
+// isMixedCase reports whether word looks deliberately cased with internal
+// capitals, like "McDonald", "PowerShell", "iPhone" or "XMLHttpRequest",
+// rather than randomly cased like "tHe" or "QuIcK".
+func isMixedCase(word string) bool {
+	base, _ := splitPossessive(word)
+
+	var letters []rune
+	for _, r := range base {
+		if unicode.IsLetter(r) {
+			letters = append(letters, r)
+		}
+	}
+
+	humps := splitHumps(letters)
+	if len(humps) < 2 {
+		return false
+	}
+
+	for i, hump := range humps {
+		if len(hump) == 1 {
+			if i > 0 {
+				return false
+			}
+			if r := hump[0]; r != 'i' && r != 'e' {
+				return false
+			}
+			continue
+		}
+
+		if i > 0 && isUpperRun(humps[i-1]) && !isUpperRun(hump) && len(hump) < 3 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func splitHumps(letters []rune) [][]rune {
+	var humps [][]rune
+	start := 0
+
+	for i := 1; i < len(letters); i++ {
+		if !unicode.IsUpper(letters[i]) {
+			continue
+		}
+		afterLower := unicode.IsLower(letters[i-1])
+		endsUpperRun := unicode.IsUpper(letters[i-1]) && i+1 < len(letters) && unicode.IsLower(letters[i+1])
+		if afterLower || endsUpperRun {
+			humps = append(humps, letters[start:i])
+			start = i
+		}
+	}
+
+	if start < len(letters) {
+		humps = append(humps, letters[start:])
+	}
+
+	return humps
+}
+
+func isUpperRun(hump []rune) bool {
+	for _, r := range hump {
+		if !unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+package titlecase
+
+import (
+	"strings"
+	"unicode"
+)
+
+var units = unitSet(
+	"KB", "MB", "GB", "TB", "PB", "KiB", "MiB", "GiB", "TiB",
+	"kbps", "Mbps", "Gbps", "Hz", "kHz", "MHz", "GHz",
+	"mm", "cm", "m", "km", "in", "ft", "yd", "mi",
+	"mg", "g", "kg", "lb", "lbs", "oz", "ml",
+	"ms", "min", "h", "hr", "hrs",
+	"px", "pt", "em", "rem", "dpi", "fps", "mph", "kph", "bit", "am", "pm",
+	"V", "W", "kW", "kWh", "mAh", "MP",
+	"k", "p", "x",
+)
+
+func unitSet(names ...string) map[string]string {
+	set := make(map[string]string, len(names))
+	for _, name := range names {
+		set[strings.ToLower(name)] = name
+	}
+	return set
+}
+
+// titleNumber cases a word that contains digits. It handles ordinals
+// ("21st"), decades ("1990s"), measurements ("10kg"), versions ("v2") and
+// short alphanumeric codes ("mp3", "3d"). It reports false for words that
+// should be cased like any other word, such as "Python3".
+func titleNumber(word string) (string, bool) {
+	digits, letters, rest := splitNumber(word)
+	if digits == "" && letters == "" {
+		return "", false
+	}
+
+	lower := strings.ToLower(word)
+
+	if digits != "" && rest == "" {
+		suffix := strings.ToLower(letters)
+		switch {
+		case suffix == "":
+			return word, true
+		case isOrdinalSuffix(digits, suffix):
+			return digits + suffix, true
+		case suffix == "s" || suffix == "'s" || suffix == "’s":
+			return digits + suffix, true
+		}
+	}
+
+	if isUpperAlphanumeric(word) {
+		return word, true
+	}
+
+	if digits != "" && rest == "" {
+		if unit, ok := units[strings.ToLower(letters)]; ok {
+			return digits + unit, true
+		}
+		if len([]rune(letters)) <= 3 {
+			return digits + strings.ToUpper(letters), true
+		}
+		return lower, true
+	}
+
+	if digits == "" && isDigits(strings.TrimRightFunc(rest, unicode.IsLetter)) {
+		if strings.EqualFold(letters, "v") && isDigits(rest) {
+			return lower, true
+		}
+		if len([]rune(letters)) <= 2 && len(rest)-len(strings.TrimRightFunc(rest, unicode.IsLetter)) <= 2 {
+			return strings.ToUpper(word), true
+		}
+	}
+
+	return "", false
+}
+
+// splitNumber splits word into a leading run of digits or letters and the
+// remainder. Words without digits return three empty strings.
+func splitNumber(word string) (string, string, string) {
+	if !strings.ContainsFunc(word, unicode.IsDigit) {
+		return "", "", ""
+	}
+
+	runes := []rune(word)
+	i := 0
+	for i < len(runes) && unicode.IsDigit(runes[i]) {
+		i++
+	}
+
+	if i > 0 {
+		return string(runes[:i]), string(runes[i:]), ""
+	}
+
+	for i < len(runes) && unicode.IsLetter(runes[i]) {
+		i++
+	}
+
+	return "", string(runes[:i]), string(runes[i:])
+}
+
+func isOrdinalSuffix(digits, suffix string) bool {
+	if suffix == "th" {
+		return true
+	}
+
+	tens := len(digits) > 1 && digits[len(digits)-2] == '1'
+	switch digits[len(digits)-1] {
+	case '1':
+		return suffix == "st" && !tens
+	case '2':
+		return suffix == "nd" && !tens
+	case '3':
+		return suffix == "rd" && !tens
+	}
+
+	return false
+}
+
+func isUpperAlphanumeric(word string) bool {
+	hasLetter := false
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			hasLetter = true
+		}
+	}
+	return hasLetter
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isLetterOrDigit(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
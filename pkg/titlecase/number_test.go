@@ -0,0 +1,140 @@
+package titlecase
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "ordinal",
+			input:    "the 21st century",
+			expected: "The 21st Century",
+		},
+		{
+			name:     "ordinals",
+			input:    "1st, 2nd, 3rd and 4th place",
+			expected: "1st, 2nd, 3rd and 4th Place",
+		},
+		{
+			name:     "teen ordinals",
+			input:    "the 11th, 12th and 13th floors",
+			expected: "The 11th, 12th and 13th Floors",
+		},
+		{
+			name:     "decade",
+			input:    "music of the 1990s",
+			expected: "Music of the 1990s",
+		},
+		{
+			name:     "decade with apostrophe",
+			input:    "fashion of the ’80s and 1990's",
+			expected: "Fashion of the ’80s and 1990's",
+		},
+		{
+			name:     "model name",
+			input:    "mp3 players",
+			expected: "MP3 Players",
+		},
+		{
+			name:     "alphanumeric codes",
+			input:    "h264 video and b2b sales",
+			expected: "H264 Video and B2B Sales",
+		},
+		{
+			name:     "version",
+			input:    "what's new in v2",
+			expected: "What's New in v2",
+		},
+		{
+			name:     "measurements",
+			input:    "a 10kg bag for 5km at 60mph",
+			expected: "A 10kg Bag for 5km at 60mph",
+		},
+		{
+			name:     "storage units",
+			input:    "upgrading from 512mb to 16gb",
+			expected: "Upgrading from 512MB to 16GB",
+		},
+		{
+			name:     "digit first codes",
+			input:    "3d printing with 2fa",
+			expected: "3D Printing with 2FA",
+		},
+		{
+			name:     "uppercase input kept",
+			input:    "watching 4K films",
+			expected: "Watching 4K Films",
+		},
+		{
+			name:     "plain numbers",
+			input:    "top 10 tips for 2024",
+			expected: "Top 10 Tips for 2024",
+		},
+		{
+			name:     "word with trailing digits",
+			input:    "upgrading to python3",
+			expected: "Upgrading to Python3",
+		},
+		{
+			name:     "hyphenated number",
+			input:    "covid-19 updates",
+			expected: "Covid-19 Updates",
+		},
+		{
+			name:     "numeronym",
+			input:    "k8s for beginners",
+			expected: "k8s for Beginners",
+		},
+		{
+			name:     "shouted ordinal",
+			input:    "THE 21ST CENTURY",
+			expected: "The 21st Century",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ToTitleCase(tt.input)
+			if err != nil {
+				t.Errorf("ToTitleCase(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("ToTitleCase(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTokenizeNumbers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{input: "21st", expected: []string{"21st"}},
+		{input: "mp3", expected: []string{"mp3"}},
+		{input: "1990's", expected: []string{"1990's"}},
+		{input: "covid-19", expected: []string{"covid-19"}},
+		{input: "v1.2", expected: []string{"v1", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var words []string
+			for _, token := range Tokenize(tt.input) {
+				if token.IsWord {
+					words = append(words, token.Text)
+				}
+			}
+			if !reflect.DeepEqual(words, tt.expected) {
+				t.Errorf("Tokenize(%q) words = %q, want %q", tt.input, words, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package titlecase
+
+import (
+	"strings"
+)
+
+// Role is the part of speech a word plays in a title, as far as it matters
+// for capitalization.
+type Role int
+
+const (
+	// RoleOther marks words with no special role, such as nouns and verbs.
+	RoleOther Role = iota
+	// RoleArticle marks "a", "an" and "the".
+	RoleArticle
+	// RolePreposition marks a preposition followed by its object, as
+	// "between" in "Life between the Wars".
+	RolePreposition
+	// RoleParticle marks a word that looks like a preposition but acts as an
+	// adverb or as part of a phrasal verb, as "up" in "Set Up Your Account".
+	RoleParticle
+	// RoleConjunction marks coordinating conjunctions such as "and" or "or".
+	RoleConjunction
+	// RoleInfinitive marks "to" before a verb, as in "How to Write".
+	RoleInfinitive
+)
+
+var articles = wordSet("a", "an", "the")
+
+var conjunctions = wordSet("and", "but", "nor", "or", "so", "yet")
+
+// prepositions leaves out words that are more often used as verbs,
+// adjectives or subordinating conjunctions, such as "like", "past" and
+// "after".
+var prepositions = wordSet(
+	"about", "above", "across", "against", "along", "amid", "among",
+	"around", "as", "at", "behind", "below", "beneath", "beside", "besides",
+	"between", "beyond", "by", "despite", "down", "during", "except", "for",
+	"from", "in", "inside", "into", "of", "off", "on", "onto", "out",
+	"outside", "over", "per", "through", "throughout", "to", "toward",
+	"towards", "under", "underneath", "unlike", "up", "upon", "via", "with",
+	"within", "without",
+)
+
+// phrasalVerbs lists verbs and the particles that combine with them.
+var phrasalVerbs = map[string][]string{
+	"back":   {"up", "out", "down", "off"},
+	"boot":   {"up"},
+	"break":  {"down", "in", "out", "up", "through"},
+	"bring":  {"up", "down", "in", "out", "back"},
+	"build":  {"up", "out"},
+	"call":   {"out", "off", "back", "up"},
+	"carry":  {"out", "on", "over"},
+	"catch":  {"up", "on"},
+	"check":  {"in", "out", "up"},
+	"clean":  {"up", "out"},
+	"come":   {"back", "in", "on", "out", "up", "over", "through"},
+	"cut":    {"off", "out", "down", "back"},
+	"drop":   {"off", "out", "in", "by"},
+	"fall":   {"back", "off", "out", "through", "down"},
+	"figure": {"out"},
+	"fill":   {"in", "out", "up"},
+	"find":   {"out"},
+	"follow": {"up", "through"},
+	"get":    {"up", "in", "out", "on", "off", "over", "through", "back", "around", "by"},
+	"give":   {"up", "in", "out", "back", "away"},
+	"go":     {"on", "out", "back", "over", "through", "down", "up", "off"},
+	"grow":   {"up"},
+	"hang":   {"up", "out", "on"},
+	"hold":   {"on", "up", "off", "back", "out"},
+	"keep":   {"up", "on", "out"},
+	"kick":   {"off", "in"},
+	"knock":  {"out", "off", "down"},
+	"lay":    {"out", "off", "down"},
+	"let":    {"down", "in", "out"},
+	"level":  {"up"},
+	"lock":   {"down", "in", "out"},
+	"log":    {"in", "out", "on", "off"},
+	"look":   {"up", "out", "back", "over", "through", "around"},
+	"make":   {"up", "out", "over"},
+	"mix":    {"up"},
+	"move":   {"on", "in", "out", "over"},
+	"opt":    {"in", "out"},
+	"pass":   {"out", "on", "by", "over"},
+	"pay":    {"off", "back", "out"},
+	"pick":   {"up", "out", "on"},
+	"plug":   {"in"},
+	"point":  {"out"},
+	"print":  {"out"},
+	"pull":   {"off", "out", "over", "through", "up"},
+	"put":    {"off", "on", "out", "up", "down"},
+	"read":   {"up", "through", "out"},
+	"roll":   {"out", "back", "over", "up"},
+	"run":    {"out", "over", "through", "up", "down"},
+	"scale":  {"up", "down", "out", "back"},
+	"set":    {"up", "off", "out", "down", "back"},
+	"settle": {"down", "in"},
+	"show":   {"up", "off", "around"},
+	"shut":   {"down", "off", "up"},
+	"sign":   {"up", "in", "out", "off", "on"},
+	"sit":    {"down", "in", "through"},
+	"slow":   {"down"},
+	"sort":   {"out"},
+	"speak":  {"up", "out"},
+	"spin":   {"up", "down", "off"},
+	"stand":  {"up", "out", "by", "down"},
+	"start":  {"up", "over", "out"},
+	"step":   {"up", "down", "in", "back"},
+	"take":   {"off", "on", "over", "up", "out", "down", "back"},
+	"tear":   {"down", "up"},
+	"think":  {"through", "over"},
+	"throw":  {"out", "away", "up"},
+	"tidy":   {"up"},
+	"turn":   {"on", "off", "up", "down", "over", "around", "out", "in"},
+	"walk":   {"through", "out", "away"},
+	"warm":   {"up"},
+	"wear":   {"out", "off"},
+	"wind":   {"down", "up"},
+	"wipe":   {"out"},
+	"work":   {"out", "on", "through"},
+	"wrap":   {"up"},
+	"write":  {"down", "up", "off", "out"},
+	"zoom":   {"in", "out"},
+}
+
+// irregularVerbs maps irregular past forms to the base form used by
+// phrasalVerbs.
+var irregularVerbs = map[string]string{
+	"broke": "break", "brought": "bring", "built": "build", "came": "come",
+	"caught": "catch", "fell": "fall", "found": "find", "gave": "give",
+	"got": "get", "gone": "go", "went": "go", "grew": "grow", "held": "hold",
+	"kept": "keep", "laid": "lay", "made": "make", "paid": "pay",
+	"ran": "run", "spoke": "speak", "spun": "spin", "stood": "stand",
+	"taken": "take", "took": "take", "tore": "tear", "thought": "think",
+	"threw": "throw", "thrown": "throw", "wore": "wear", "wound": "wind",
+	"wrote": "write", "written": "write",
+}
+
+// infinitiveCues lists words after which "to" usually introduces a verb.
+var infinitiveCues = wordSet(
+	"how", "what", "where", "when", "why", "whether", "ways", "way",
+	"want", "wants", "wanted", "need", "needs", "learn", "learning",
+	"going", "trying", "try", "time", "able", "ready", "easy", "hard",
+	"reasons", "used", "have", "has",
+)
+
+// tagRoles returns the Role of each token of a title. Tokens that are not
+// words get RoleOther.
+func tagRoles(tokens []Token) []Role {
+	roles := make([]Role, len(tokens))
+
+	for i, token := range tokens {
+		if !token.IsWord || token.Protected {
+			continue
+		}
+
+		word := strings.ToLower(token.Text)
+		prev := adjacentWord(tokens, i, -1)
+		next := adjacentWord(tokens, i, 1)
+
+		switch {
+		case articles[word]:
+			roles[i] = RoleArticle
+		case conjunctions[word]:
+			roles[i] = RoleConjunction
+		case word == "to" && (infinitiveCues[prev] || isVerb(next)):
+			roles[i] = RoleInfinitive
+		case prepositions[word]:
+			if next == "" || isPhrasalVerb(tokens, i, word) || isDiscount(tokens, i, word) {
+				roles[i] = RoleParticle
+			} else {
+				roles[i] = RolePreposition
+			}
+		}
+	}
+
+	return roles
+}
+
+// adjacentWord returns the lowercased word next to tokens[index] in
+// direction dir. It returns "" when punctuation or the end of the title comes
+// first.
+func adjacentWord(tokens []Token, index, dir int) string {
+	if i := adjacentIndex(tokens, index, dir); i >= 0 {
+		return strings.ToLower(tokens[i].Text)
+	}
+	return ""
+}
+
+// adjacentIndex returns the index of the word next to tokens[index] in
+// direction dir, looking past whitespace and, after the word, past opening
+// marks such as "(" or quotes. It returns -1 when other punctuation or the
+// end of the title comes first.
+func adjacentIndex(tokens []Token, index, dir int) int {
+	for i := index + dir; i >= 0 && i < len(tokens); i += dir {
+		token := tokens[i]
+		switch {
+		case token.IsWord:
+			return i
+		case token.IsPunctuation:
+			if dir < 0 || !endsWithOpeningMark(strings.TrimSpace(token.Text)) {
+				return -1
+			}
+		}
+	}
+	return -1
+}
+
+func isVerb(word string) bool {
+	_, ok := phrasalVerbs[word]
+	return ok
+}
+
+// determiners lists words that turn a following verb form into a noun, as
+// "walk" in "A Walk through the Forest".
+var determiners = wordSet(
+	"a", "an", "the", "this", "that", "these", "those", "my", "your", "his",
+	"her", "its", "our", "their", "every", "each", "no",
+)
+
+// isPhrasalVerb reports whether the word before tokens[index] is a verb, in
+// any inflected form, that combines with particle into a phrasal verb such
+// as "set up" or "logging in".
+func isPhrasalVerb(tokens []Token, index int, particle string) bool {
+	i := adjacentIndex(tokens, index, -1)
+	if i < 0 || determiners[adjacentWord(tokens, i, -1)] {
+		return false
+	}
+
+	for _, base := range verbBases(strings.ToLower(tokens[i].Text)) {
+		for _, p := range phrasalVerbs[base] {
+			if p == particle {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isDiscount reports whether tokens[index] is "off" after an amount, as in
+// "50% Off Everything" or "$10 Off".
+func isDiscount(tokens []Token, index int, word string) bool {
+	if word != "off" {
+		return false
+	}
+
+	for i := index - 1; i >= 0; i-- {
+		text := strings.TrimSpace(tokens[i].Text)
+		switch {
+		case text == "" || text == "%":
+		case tokens[i].IsWord:
+			return text[0] >= '0' && text[0] <= '9' || strings.EqualFold(text, "percent")
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// verbBases returns the candidate base forms of an inflected verb, such as
+// "set" for "sets" and "setting" or "log" for "logged".
+func verbBases(word string) []string {
+	bases := []string{word}
+	if base, ok := irregularVerbs[word]; ok {
+		bases = append(bases, base)
+	}
+
+	for _, suffix := range []string{"s", "es", "d", "ed", "ing"} {
+		stem, ok := strings.CutSuffix(word, suffix)
+		if !ok || len(stem) < 2 {
+			continue
+		}
+		bases = append(bases, stem, stem+"e")
+		if n := len(stem); stem[n-1] == stem[n-2] {
+			bases = append(bases, stem[:n-1])
+		}
+	}
+
+	return bases
+}
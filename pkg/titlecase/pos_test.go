@@ -0,0 +1,125 @@
+package titlecase
+
+import (
+	"testing"
+)
+
+func TestPartOfSpeech(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    Style
+		input    string
+		expected string
+	}{
+		{
+			name:     "phrasal verb particle",
+			input:    "set up your account",
+			expected: "Set Up Your Account",
+		},
+		{
+			name:     "inflected phrasal verb",
+			input:    "backing up your data",
+			expected: "Backing Up Your Data",
+		},
+		{
+			name:     "irregular phrasal verb",
+			input:    "how we took over the market",
+			expected: "How We Took Over the Market",
+		},
+		{
+			name:     "particles around a conjunction",
+			input:    "logging in and signing out",
+			expected: "Logging In and Signing Out",
+		},
+		{
+			name:     "preposition after phrasal verb",
+			input:    "how to log in to your account",
+			expected: "How to Log In to Your Account",
+		},
+		{
+			name:     "long prepositions",
+			input:    "life between the wars and without hope",
+			expected: "Life between the Wars and without Hope",
+		},
+		{
+			name:     "preposition through",
+			input:    "a journey through time",
+			expected: "A Journey through Time",
+		},
+		{
+			name:     "noun before preposition",
+			input:    "a walk through the forest",
+			expected: "A Walk through the Forest",
+		},
+		{
+			name:     "adverb before punctuation",
+			input:    "what lies beyond, and why",
+			expected: "What Lies Beyond, and Why",
+		},
+		{
+			name:     "preposition before opening quote",
+			input:    "notes on \"the great gatsby\"",
+			expected: "Notes on \"The Great Gatsby\"",
+		},
+		{
+			name:     "ap capitalizes particles",
+			style:    AP,
+			input:    "how to set up a server",
+			expected: "How to Set Up a Server",
+		},
+		{
+			name:     "ap keeps long prepositions capitalized",
+			style:    AP,
+			input:    "life between the wars",
+			expected: "Life Between the Wars",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := New(Options{Style: tt.style}).Title(tt.input)
+			if err != nil {
+				t.Errorf("Title(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("Title(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTagRoles(t *testing.T) {
+	tests := []struct {
+		input    string
+		word     string
+		expected Role
+	}{
+		{"the cat", "the", RoleArticle},
+		{"cats and dogs", "and", RoleConjunction},
+		{"how to write", "to", RoleInfinitive},
+		{"learning to set up", "to", RoleInfinitive},
+		{"welcome to paris", "to", RolePreposition},
+		{"set up the server", "up", RoleParticle},
+		{"shut down now", "down", RoleParticle},
+		{"walk down the street", "down", RolePreposition},
+		{"life between wars", "between", RolePreposition},
+		{"what lies beyond: more", "beyond", RoleParticle},
+		{"50% off everything", "off", RoleParticle},
+		{"20 percent off", "off", RoleParticle},
+		{"50% of users", "of", RolePreposition},
+		{"a quick fox", "quick", RoleOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			tokens := Tokenize(tt.input)
+			roles := tagRoles(tokens)
+			for i, token := range tokens {
+				if token.Text == tt.word && roles[i] != tt.expected {
+					t.Errorf("tagRoles(%q)[%q] = %d, want %d", tt.input, tt.word, roles[i], tt.expected)
+				}
+			}
+		})
+	}
+}
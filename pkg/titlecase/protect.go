@@ -0,0 +1,262 @@
+package titlecase
+
+import (
+	"strings"
+	"unicode"
+)
+
+// protectedOpeners and protectedClosers hold the punctuation that may wrap a
+// URL or path in running text without being part of it.
+const (
+	protectedOpeners = "([{<\"'“‘«„‚‹"
+	protectedClosers = ".,;:!?)]}>\"'”’»›"
+)
+
+// protectedSpan looks at the whitespace-delimited chunk starting at
+// runes[index] and returns the bounds of the URL, email address, path or
+// dotted name it contains, without surrounding punctuation. It returns
+// start == end when the chunk is ordinary text.
+func protectedSpan(runes []rune, index int) (int, int) {
+	end := index
+	for end < len(runes) && !unicode.IsSpace(runes[end]) {
+		end++
+	}
+
+	start := index
+	for start < end && strings.ContainsRune(protectedOpeners, runes[start]) {
+		start++
+	}
+	for end > start && strings.ContainsRune(protectedClosers, runes[end-1]) {
+		end--
+	}
+
+	if start == end || !isProtected(string(runes[start:end])) {
+		return index, index
+	}
+	return start, end
+}
+
+func isProtected(text string) bool {
+	switch {
+	case strings.Contains(text, "://"):
+		return true
+	case strings.HasPrefix(strings.ToLower(text), "www."):
+		return true
+	case strings.Contains(text, "@"):
+		return isEmail(text)
+	case strings.ContainsAny(text, "/\\"):
+		return isPath(text)
+	default:
+		return isDottedName(text)
+	}
+}
+
+func isEmail(text string) bool {
+	at := strings.LastIndex(text, "@")
+	return at > 0 && isDottedName(text[at+1:])
+}
+
+func isPath(text string) bool {
+	for _, prefix := range []string{"/", "./", "../", "~/", `\\`} {
+		if strings.HasPrefix(text, prefix) {
+			return true
+		}
+	}
+	if strings.HasSuffix(text, "/") || strings.Contains(text, `:\`) {
+		return true
+	}
+
+	last := text[strings.LastIndexAny(text, "/\\")+1:]
+	dot := strings.LastIndex(last, ".")
+	return dot > 0 && dot < len(last)-1
+}
+
+// isDottedName reports whether text is a domain name or a dotted identifier
+// such as "example.com", "node.js" or "os.Exit". Abbreviations made of single
+// letters, such as "e.g" or "U.S", and version numbers such as "v1.2" are not
+// dotted names.
+func isDottedName(text string) bool {
+	segments := strings.Split(text, ".")
+	if len(segments) < 2 {
+		return false
+	}
+	last := []rune(segments[len(segments)-1])
+	if len(last) == 0 || !unicode.IsLetter(last[0]) {
+		return false
+	}
+
+	long := false
+	for _, segment := range segments {
+		if segment == "" {
+			return false
+		}
+		for _, r := range segment {
+			if !isLetterOrDigit(r) && r != '_' && r != '-' {
+				return false
+			}
+		}
+		if len([]rune(segment)) > 1 {
+			long = true
+		}
+	}
+
+	return long
+}
+
+// printfFlags and printfVerbs hold the flag characters and verbs of a Go or C
+// printf directive such as "%-8.2f".
+const (
+	printfFlags = "+-#0"
+	printfVerbs = "bcdefgopqstvxEFGOTUX%"
+)
+
+// inlineSpan returns the end of the code span, placeholder, printf verb or
+// escaped text starting at runes[index], or index when there is none.
+func inlineSpan(runes []rune, index int, escape string) int {
+	if escape != "" {
+		if end := escapedSpan(runes, index, []rune(escape)); end > index {
+			return end
+		}
+	}
+
+	switch runes[index] {
+	case '`':
+		return codeSpan(runes, index)
+	case '{':
+		return placeholder(runes, index)
+	case '%':
+		return printfVerb(runes, index)
+	}
+
+	return index
+}
+
+func codeSpan(runes []rune, index int) int {
+	n := 0
+	for index+n < len(runes) && runes[index+n] == '`' {
+		n++
+	}
+
+	for i := index + n; i < len(runes); {
+		if runes[i] != '`' {
+			i++
+			continue
+		}
+		run := 0
+		for i+run < len(runes) && runes[i+run] == '`' {
+			run++
+		}
+		if run == n {
+			return i + run
+		}
+		i += run
+	}
+
+	return index
+}
+
+// placeholder matches "{{ ... }}" template actions and "{name}" format
+// placeholders.
+func placeholder(runes []rune, index int) int {
+	if index+1 < len(runes) && runes[index+1] == '{' {
+		for i := index + 2; i+1 < len(runes); i++ {
+			if runes[i] == '}' && runes[i+1] == '}' {
+				return i + 2
+			}
+		}
+		return index
+	}
+
+	for i := index + 1; i < len(runes); i++ {
+		switch {
+		case runes[i] == '}':
+			if i == index+1 {
+				return index
+			}
+			return i + 1
+		case runes[i] == '{' || unicode.IsSpace(runes[i]):
+			return index
+		}
+	}
+
+	return index
+}
+
+func printfVerb(runes []rune, index int) int {
+	i := index + 1
+	for i < len(runes) && strings.ContainsRune(printfFlags, runes[i]) {
+		i++
+	}
+	i = skipPrintfArg(runes, i)
+	for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '*') {
+		i++
+	}
+	if i < len(runes) && runes[i] == '.' {
+		i++
+		for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '*') {
+			i++
+		}
+	}
+	i = skipPrintfArg(runes, i)
+
+	if i >= len(runes) || !strings.ContainsRune(printfVerbs, runes[i]) {
+		return index
+	}
+	if i+1 < len(runes) && unicode.IsLetter(runes[i+1]) {
+		return index
+	}
+	return i + 1
+}
+
+// skipPrintfArg skips an explicit argument index such as "[1]".
+func skipPrintfArg(runes []rune, i int) int {
+	if i >= len(runes) || runes[i] != '[' {
+		return i
+	}
+	j := i + 1
+	for j < len(runes) && unicode.IsDigit(runes[j]) {
+		j++
+	}
+	if j == i+1 || j >= len(runes) || runes[j] != ']' {
+		return i
+	}
+	return j + 1
+}
+
+func escapedSpan(runes []rune, index int, marker []rune) int {
+	if !hasRunePrefix(runes[index:], marker) {
+		return index
+	}
+
+	start := index + len(marker)
+	for i := start + 1; i <= len(runes)-len(marker); i++ {
+		if hasRunePrefix(runes[i:], marker) {
+			return i + len(marker)
+		}
+	}
+
+	return index
+}
+
+func hasRunePrefix(runes []rune, prefix []rune) bool {
+	if len(runes) < len(prefix) {
+		return false
+	}
+	for i, r := range prefix {
+		if runes[i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// unescape removes the escape markers around protected text.
+func (t *Titler) unescape(text string) string {
+	if t.escape == "" || len(text) <= 2*len(t.escape) {
+		return text
+	}
+	if strings.HasPrefix(text, t.escape) && strings.HasSuffix(text, t.escape) {
+		return text[len(t.escape) : len(text)-len(t.escape)]
+	}
+	return text
+}
@@ -0,0 +1,266 @@
+package titlecase
+
+import (
+	"testing"
+)
+
+func TestProtectedTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "domain name",
+			input:    "moving our blog to example.com",
+			expected: "Moving Our Blog to example.com",
+		},
+		{
+			name:     "url",
+			input:    "see https://example.com/docs/Getting-Started for details",
+			expected: "See https://example.com/docs/Getting-Started for Details",
+		},
+		{
+			name:     "www address",
+			input:    "visit www.example.org today",
+			expected: "Visit www.example.org Today",
+		},
+		{
+			name:     "email address",
+			input:    "send feedback to user@host.io",
+			expected: "Send Feedback to user@host.io",
+		},
+		{
+			name:     "relative path",
+			input:    "update docs/README.md for the release",
+			expected: "Update docs/README.md for the Release",
+		},
+		{
+			name:     "absolute path",
+			input:    "logs now live in /var/log/gtl",
+			expected: "Logs Now Live in /var/log/gtl",
+		},
+		{
+			name:     "windows path",
+			input:    `config moved to C:\Program Files`,
+			expected: `Config Moved to C:\Program Files`,
+		},
+		{
+			name:     "dotted identifier",
+			input:    "why you should not call os.Exit in libraries",
+			expected: "Why You Should Not Call os.Exit in Libraries",
+		},
+		{
+			name:     "first word",
+			input:    "node.js in production",
+			expected: "node.js in Production",
+		},
+		{
+			name:     "surrounding punctuation",
+			input:    "notes on (example.com), and more",
+			expected: "Notes on (example.com), and More",
+		},
+		{
+			name:     "trailing period",
+			input:    "we moved to example.com.",
+			expected: "We Moved to example.com.",
+		},
+		{
+			name:     "last word keeps small word before it lowercase",
+			input:    "a guide to example.com",
+			expected: "A Guide to example.com",
+		},
+		{
+			name:     "abbreviation not protected",
+			input:    "tools, e.g. linters",
+			expected: "Tools, E.G. Linters",
+		},
+		{
+			name:     "slash between words not protected",
+			input:    "input/output and/or more",
+			expected: "Input/Output and/or More",
+		},
+		{
+			name:     "shouting title",
+			input:    "WELCOME TO EXAMPLE.COM",
+			expected: "Welcome to EXAMPLE.COM",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ToTitleCase(tt.input)
+			if err != nil {
+				t.Errorf("ToTitleCase(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("ToTitleCase(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProtectedSentence(t *testing.T) {
+	input := "Publishing Packages To registry.npmjs.org From ./Scripts/Release.sh"
+	expected := "Publishing packages to registry.npmjs.org from ./Scripts/Release.sh"
+
+	result, err := ToSentenceCase(input)
+	if err != nil {
+		t.Fatalf("ToSentenceCase(%q) returned unexpected error: %v", input, err)
+	}
+	if result != expected {
+		t.Errorf("ToSentenceCase(%q) = %q, want %q", input, result, expected)
+	}
+}
+
+func TestIsProtected(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected bool
+	}{
+		{"example.com", true},
+		{"http://example.com", true},
+		{"ftp://host/file", true},
+		{"user@host.io", true},
+		{"@user", false},
+		{"docs/README.md", true},
+		{"./run", true},
+		{"~/notes", true},
+		{"src/", true},
+		{"and/or", false},
+		{"node.js", true},
+		{"os.Exit", true},
+		{"e.g", false},
+		{"U.S.A", false},
+		{"wait...what", false},
+		{"v1.2.3", false},
+		{"hello", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if result := isProtected(tt.text); result != tt.expected {
+				t.Errorf("isProtected(%q) = %t, want %t", tt.text, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProtectedSpans(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		input    string
+		expected string
+	}{
+		{
+			name:     "code span",
+			input:    "configuring the `max_conns` option",
+			expected: "Configuring the `max_conns` Option",
+		},
+		{
+			name:     "code span with spaces",
+			input:    "using `go test -run foo` in practice",
+			expected: "Using `go test -run foo` in Practice",
+		},
+		{
+			name:     "double backtick span",
+			input:    "escaping ``a ` tick`` in markdown",
+			expected: "Escaping ``a ` tick`` in Markdown",
+		},
+		{
+			name:     "unclosed backtick",
+			input:    "the ` character",
+			expected: "The ` Character",
+		},
+		{
+			name:     "template action",
+			input:    "welcome back, {{.Name}}",
+			expected: "Welcome Back, {{.Name}}",
+		},
+		{
+			name:     "template action with spaces",
+			input:    "hello {{ .user.name }} and friends",
+			expected: "Hello {{ .user.name }} and Friends",
+		},
+		{
+			name:     "format placeholder",
+			input:    "deleted {count} files from {dir}",
+			expected: "Deleted {count} Files from {dir}",
+		},
+		{
+			name:     "printf verbs",
+			input:    "found %d errors in %s",
+			expected: "Found %d Errors in %s",
+		},
+		{
+			name:     "printf verb with width",
+			input:    "total: %-8.2f percent",
+			expected: "Total: %-8.2f Percent",
+		},
+		{
+			name:     "percent sign",
+			input:    "save 50% off everything",
+			expected: "Save 50% Off Everything",
+		},
+		{
+			name:     "escaped word",
+			opts:     Options{Escape: "="},
+			input:    "why =iphone= is spelled that way",
+			expected: "Why iphone Is Spelled That Way",
+		},
+		{
+			name:     "escaped phrase",
+			opts:     Options{Escape: "!!"},
+			input:    "the !!lord of the rings!! review",
+			expected: "The lord of the rings Review",
+		},
+		{
+			name:     "unmatched escape marker",
+			opts:     Options{Escape: "="},
+			input:    "a = b for beginners",
+			expected: "A = B for Beginners",
+		},
+		{
+			name:     "escape disabled",
+			input:    "why =iphone= is spelled that way",
+			expected: "Why =iPhone= Is Spelled That Way",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := New(tt.opts).Title(tt.input)
+			if err != nil {
+				t.Errorf("Title(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("Title(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProtectedSpansLintAndSentence(t *testing.T) {
+	titler := New(Options{Escape: "="})
+
+	violations, err := titler.Lint("Configuring `max_conns` with =iphone=")
+	if err != nil {
+		t.Fatalf("Lint returned unexpected error: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("Lint returned %v, want no violations", violations)
+	}
+
+	input := "Printing %v With {{.Name}} And =iOS="
+	expected := "Printing %v with {{.Name}} and iOS"
+	result, err := titler.Sentence(input)
+	if err != nil {
+		t.Fatalf("Sentence(%q) returned unexpected error: %v", input, err)
+	}
+	if result != expected {
+		t.Errorf("Sentence(%q) = %q, want %q", input, result, expected)
+	}
+}
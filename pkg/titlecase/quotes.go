@@ -0,0 +1,36 @@
+package titlecase
+
+import (
+	"unicode/utf8"
+)
+
+// quoteMarks holds the straight, typographic and locale-specific quotation
+// marks that can open a quotation, such as “ ‘ « » „ ‚ ‹ › 「 and 『.
+const quoteMarks = "\"'“”‘’«»„‚‹›「『"
+
+// isSpacedOpeningMark reports whether punctuation is a guillemet that opens
+// a quotation followed by a space, as in French « texte ».
+func isSpacedOpeningMark(punctuation string) bool {
+	return punctuation == "«" || punctuation == "‹"
+}
+
+// isApostrophe reports whether r can join the letters of a contraction, as
+// in "don't" or "don’t".
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’' || r == 'ʼ'
+}
+
+func splitPossessive(word string) (string, string) {
+	s, size := utf8.DecodeLastRuneInString(word)
+	if s != 's' && s != 'S' {
+		return word, ""
+	}
+
+	rest := word[:len(word)-size]
+	apostrophe, size := utf8.DecodeLastRuneInString(rest)
+	if !isApostrophe(apostrophe) || len(rest) == size {
+		return word, ""
+	}
+
+	return rest[:len(rest)-size], word[len(rest)-size:]
+}
@@ -0,0 +1,116 @@
+package titlecase
+
+import (
+	"testing"
+)
+
+func TestTypographicQuotes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "curly apostrophe contraction",
+			input:    "don’t stop believing",
+			expected: "Don’t Stop Believing",
+		},
+		{
+			name:     "modifier letter apostrophe",
+			input:    "it ʼs over",
+			expected: "It ʼs Over",
+		},
+		{
+			name:     "curly possessive with dictionary word",
+			input:    "inside github’s data centers",
+			expected: "Inside GitHub’s Data Centers",
+		},
+		{
+			name:     "curly double quotes",
+			input:    "he said “the end” and left",
+			expected: "He Said “The End” and Left",
+		},
+		{
+			name:     "curly single quotes",
+			input:    "a ‘the’ too many",
+			expected: "A ‘The’ Too Many",
+		},
+		{
+			name:     "guillemets",
+			input:    "the word « and » in french",
+			expected: "The Word « And » in French",
+		},
+		{
+			name:     "german quotes",
+			input:    "the film „the lives of others“ reviewed",
+			expected: "The Film „The Lives of Others“ Reviewed",
+		},
+		{
+			name:     "reversed guillemets",
+			input:    "the book »of mice and men« reviewed",
+			expected: "The Book »Of Mice and Men« Reviewed",
+		},
+		{
+			name:     "closing quote not an opener",
+			input:    "‘hello world’ and other stories",
+			expected: "‘Hello World’ and Other Stories",
+		},
+		{
+			name:     "closing curly quote not an opener",
+			input:    "the “end” of it all",
+			expected: "The “End” of It All",
+		},
+		{
+			name:     "opening parenthesis",
+			input:    "the guide (for the impatient)",
+			expected: "The Guide (For the Impatient)",
+		},
+		{
+			name:     "after colon",
+			input:    "star wars: a new hope",
+			expected: "Star Wars: A New Hope",
+		},
+		{
+			name:     "shouting with curly possessive",
+			input:    "UNESCO’S WORLD HERITAGE LIST",
+			expected: "UNESCO’s World Heritage List",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ToTitleCase(tt.input)
+			if err != nil {
+				t.Errorf("ToTitleCase(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("ToTitleCase(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSplitPossessive(t *testing.T) {
+	tests := []struct {
+		word   string
+		base   string
+		suffix string
+	}{
+		{word: "GitHub's", base: "GitHub", suffix: "'s"},
+		{word: "GitHub’s", base: "GitHub", suffix: "’s"},
+		{word: "CEO'S", base: "CEO", suffix: "'S"},
+		{word: "APIs", base: "APIs", suffix: ""},
+		{word: "'s", base: "'s", suffix: ""},
+		{word: "s", base: "s", suffix: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			base, suffix := splitPossessive(tt.word)
+			if base != tt.base || suffix != tt.suffix {
+				t.Errorf("splitPossessive(%q) = %q, %q, want %q, %q", tt.word, base, suffix, tt.base, tt.suffix)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package titlecase
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ToSentenceCase converts text to sentence case using the default Options.
+func ToSentenceCase(text string) (string, error) {
+	return defaultTitler.Sentence(text)
+}
+
+// Sentence converts text to sentence case. The first word and the first word
+// after a colon or a period, question mark or exclamation mark are
+// capitalized. Acronyms, initials such as "U.K.", the pronoun "I",
+// mixed-case words and Dictionary words keep their casing, and every other
+// word is lowercased. Protected text is left unchanged as in Title. It
+// returns the same errors as Title.
+func (t *Titler) Sentence(text string) (string, error) {
+	tokens, err := t.tokenizeInput(text)
+	if err != nil {
+		return "", err
+	}
+
+	original := tokens
+	if isShouting(tokens) {
+		tokens = t.normalizeShouting(tokens)
+	}
+
+	var result strings.Builder
+	isFirstWord := true
+
+	for i, token := range tokens {
+		if !token.IsWord {
+			result.WriteString(token.Text)
+			continue
+		}
+		if token.Protected {
+			result.WriteString(t.unescape(token.Text))
+			isFirstWord = false
+			continue
+		}
+		if isInitial(original, i) {
+			result.WriteString(original[i].Text)
+			isFirstWord = false
+			continue
+		}
+
+		sentenceWord, err := t.sentenceWord(token.Text, isFirstWord || followsBoundaryOf(tokens, i, sentenceBoundary))
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(sentenceWord)
+		isFirstWord = false
+	}
+
+	return result.String(), nil
+}
+
+func (t *Titler) sentenceWord(word string, capitalize bool) (string, error) {
+	if exact, ok := t.lookupWord(word); ok {
+		return exact, nil
+	}
+	if pronoun, ok := pronounI(word); ok {
+		return pronoun, nil
+	}
+
+	parts := strings.Split(word, "-")
+
+	for i, part := range parts {
+		if exact, ok := t.lookupWord(part); ok {
+			parts[i] = exact
+			continue
+		}
+		if number, ok := titleNumber(part); ok {
+			parts[i] = number
+			continue
+		}
+		if t.shouldPreserveOriginalCasing(part) {
+			continue
+		}
+
+		parts[i] = strings.ToLower(part)
+		if capitalize && i == 0 {
+			capitalized, err := capitalizeFirst(parts[i])
+			if err != nil {
+				return "", err
+			}
+			parts[i] = capitalized
+		}
+	}
+
+	return strings.Join(parts, "-"), nil
+}
+
+// pronounI returns the pronoun "I" and its contractions, such as "I'm",
+// with the "I" capitalized.
+func pronounI(word string) (string, bool) {
+	lower := strings.ToLower(word)
+	if lower == "i" {
+		return "I", true
+	}
+
+	rest, ok := strings.CutPrefix(lower, "i")
+	if !ok {
+		return "", false
+	}
+	apostrophe, size := utf8.DecodeRuneInString(rest)
+	switch rest[size:] {
+	case "m", "ve", "ll", "d":
+		if isApostrophe(apostrophe) {
+			return "I" + rest, true
+		}
+	}
+	return "", false
+}
+
+// isInitial reports whether the word at index is a single uppercase letter
+// followed by a period, as in "U.K." or "J. R. R. Tolkien".
+func isInitial(tokens []Token, index int) bool {
+	word := tokens[index].Text
+	r, size := utf8.DecodeRuneInString(word)
+	return size == len(word) && unicode.IsUpper(r) &&
+		index+1 < len(tokens) && strings.HasPrefix(tokens[index+1].Text, ".")
+}
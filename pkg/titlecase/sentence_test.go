@@ -0,0 +1,119 @@
+package titlecase
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestToSentenceCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "lowercase input",
+			input:    "the quick brown fox",
+			expected: "The quick brown fox",
+		},
+		{
+			name:     "title case input",
+			input:    "The Lord of the Rings",
+			expected: "The lord of the rings",
+		},
+		{
+			name:     "acronyms preserved",
+			input:    "Using the API From the USA",
+			expected: "Using the API from the USA",
+		},
+		{
+			name:     "after colon",
+			input:    "getting started: Your First Project",
+			expected: "Getting started: Your first project",
+		},
+		{
+			name:     "after colon inside quotes",
+			input:    `chapter one: "A New Hope"`,
+			expected: `Chapter one: "A new hope"`,
+		},
+		{
+			name:     "hyphenated words",
+			input:    "Self-Driving Cars and COVID-Related Delays",
+			expected: "Self-driving cars and COVID-related delays",
+		},
+		{
+			name:     "leading punctuation",
+			input:    `"HELLO" Says The Robot`,
+			expected: `"HELLO" says the robot`,
+		},
+		{
+			name:     "contractions",
+			input:    "It's Time To Go",
+			expected: "It's time to go",
+		},
+		{
+			name:     "numbers and units",
+			input:    "the best mp3 players for the 21ST century",
+			expected: "The best MP3 players for the 21st century",
+		},
+		{
+			name:     "pronoun I",
+			input:    "What I Learned In School and what i'm doing now",
+			expected: "What I learned in school and what I'm doing now",
+		},
+		{
+			name:     "dotted initialisms",
+			input:    "Living In The U.K. Today With A.I.",
+			expected: "Living in the U.K. today with A.I.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ToSentenceCase(tt.input)
+			if err != nil {
+				t.Errorf("ToSentenceCase(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("ToSentenceCase(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestToSentenceCaseErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expectedErr error
+	}{
+		{
+			name:        "empty string",
+			input:       "",
+			expectedErr: ErrEmptyInput,
+		},
+		{
+			name:        "punctuation only",
+			input:       "?!",
+			expectedErr: ErrEmptyInput,
+		},
+		{
+			name:        "invalid unicode",
+			input:       "hello \xff world",
+			expectedErr: ErrInvalidUnicode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ToSentenceCase(tt.input)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("ToSentenceCase(%q) error = %v, want %v", tt.input, err, tt.expectedErr)
+			}
+			if err != nil && result != "" {
+				t.Errorf("ToSentenceCase(%q) returned non-empty result on error: %q", tt.input, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package titlecase
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// StreamOptions configures Stream.
+type StreamOptions struct {
+	// Delimiter is the single byte that ends each record, such as "\x00".
+	// An empty Delimiter splits on newlines, and a "\r" before the newline
+	// is kept out of the record and written back unchanged.
+	Delimiter string
+	// Transform converts each record. A nil Transform uses Titler.Title;
+	// pass Titler.Sentence for sentence case.
+	Transform func(string) (string, error)
+	// MaxLength is the longest record, in bytes, that is read. Zero uses
+	// the limit of the Titler and a negative value removes it. A longer
+	// record stops the stream with a *LengthError without being held in
+	// memory. Transform may apply its own limit, as Titler.Title does.
+	MaxLength int
+}
+
+// ErrInvalidDelimiter is returned by Stream when StreamOptions.Delimiter is
+// longer than one byte.
+var ErrInvalidDelimiter = errors.New("stream delimiter must be a single byte")
+
+// RecordError reports a record that could not be converted.
+type RecordError struct {
+	// Record is the 1-based number of the record.
+	Record int
+	// Err is the error returned for the record, such as ErrInputTooLong.
+	Err error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("record %d: %v", e.Record, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// Stream converts each record of r with the default Options and writes it
+// to w.
+func Stream(ctx context.Context, r io.Reader, w io.Writer, opts StreamOptions) error {
+	return defaultTitler.Stream(ctx, r, w, opts)
+}
+
+// Stream reads records from r, converts each one and writes it to w followed
+// by its original delimiter. Only one record is held in memory at a time, so
+// the length of r is unlimited. Records without words, such as blank lines,
+// are written unchanged.
+//
+// Stream stops at the first record that cannot be converted and returns a
+// *RecordError. It also stops with ctx.Err() once ctx is done; cancellation
+// is checked between records, so a blocked read is not interrupted.
+func (t *Titler) Stream(ctx context.Context, r io.Reader, w io.Writer, opts StreamOptions) error {
+	delimiter := byte('\n')
+	switch len(opts.Delimiter) {
+	case 0:
+	case 1:
+		delimiter = opts.Delimiter[0]
+	default:
+		return ErrInvalidDelimiter
+	}
+	transform := opts.Transform
+	if transform == nil {
+		transform = t.Title
+	}
+	limit := opts.MaxLength
+	if limit == 0 {
+		limit = t.maxLength
+	}
+
+	reader := bufio.NewReader(r)
+	for number := 1; ; number++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		record, readErr := readRecord(reader, delimiter, limit)
+		if errors.Is(readErr, ErrInputTooLong) {
+			return &RecordError{Record: number, Err: readErr}
+		}
+		if readErr != nil && readErr != io.EOF {
+			return readErr
+		}
+		if len(record) == 0 {
+			return nil
+		}
+
+		end := recordEnd(record, delimiter)
+		text, ending := string(record[:end]), string(record[end:])
+		result, err := transform(text)
+		if errors.Is(err, ErrEmptyInput) {
+			result, err = text, nil
+		}
+		if err != nil {
+			return &RecordError{Record: number, Err: err}
+		}
+
+		if _, err := io.WriteString(w, result+ending); err != nil {
+			return err
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
+
+func readRecord(reader *bufio.Reader, delimiter byte, limit int) ([]byte, error) {
+	var record []byte
+	skipped := 0
+	for {
+		chunk, err := reader.ReadSlice(delimiter)
+		record = append(record, chunk...)
+
+		tooLong := limit > 0 && skipped+recordEnd(record, delimiter) > limit
+		if tooLong && len(record) > 2 {
+			// Keep only what recordEnd needs to measure the rest.
+			skipped += len(record) - 2
+			record = append(record[:0], record[len(record)-2:]...)
+		}
+
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if tooLong {
+			return nil, &LengthError{Length: skipped + recordEnd(record, delimiter), Limit: limit}
+		}
+		return record, err
+	}
+}
+
+func recordEnd(record []byte, delimiter byte) int {
+	end := len(record)
+	if end > 0 && record[end-1] == delimiter {
+		end--
+		if delimiter == '\n' && end > 0 && record[end-1] == '\r' {
+			end--
+		}
+	}
+	return end
+}
@@ -0,0 +1,166 @@
+package titlecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     StreamOptions
+		expected string
+	}{
+		{
+			name:     "lines",
+			input:    "the first title\n\nthe second title",
+			expected: "The First Title\n\nThe Second Title",
+		},
+		{
+			name:     "crlf line endings",
+			input:    "the end\r\n...\r\n",
+			expected: "The End\r\n...\r\n",
+		},
+		{
+			name:     "nul delimiter",
+			input:    "the end\x00a new hope\nfor all\x00",
+			opts:     StreamOptions{Delimiter: "\x00"},
+			expected: "The End\x00A New Hope\nfor All\x00",
+		},
+		{
+			name:     "sentence transform",
+			input:    "Getting Started With The API\n",
+			opts:     StreamOptions{Transform: New(Options{}).Sentence},
+			expected: "Getting started with the API\n",
+		},
+		{
+			name:     "total input over the title limit",
+			input:    strings.Repeat("the end\n", MaxInputLength),
+			expected: strings.Repeat("The End\n", MaxInputLength),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := Stream(context.Background(), strings.NewReader(tt.input), &out, tt.opts); err != nil {
+				t.Fatalf("Stream(%q) returned unexpected error: %v", tt.input, err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("Stream(%q) = %q, want %q", tt.input, out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestStreamErrors(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		input    string
+		opts     StreamOptions
+		expected error
+		record   int
+		output   string
+	}{
+		{
+			name:     "invalid unicode",
+			ctx:      context.Background(),
+			input:    "the end\nbad \xff\nnever read\n",
+			expected: ErrInvalidUnicode,
+			record:   2,
+			output:   "The End\n",
+		},
+		{
+			name:     "record too long",
+			ctx:      context.Background(),
+			input:    "the end\n" + strings.Repeat("a", MaxInputLength+1) + "\n",
+			expected: ErrInputTooLong,
+			record:   2,
+			output:   "The End\n",
+		},
+		{
+			name:     "lower limit",
+			ctx:      context.Background(),
+			input:    "the end\na longer title\n",
+			opts:     StreamOptions{MaxLength: 8},
+			expected: ErrInputTooLong,
+			record:   2,
+			output:   "The End\n",
+		},
+		{
+			name:     "transform error",
+			ctx:      context.Background(),
+			input:    "the end\n",
+			opts:     StreamOptions{Transform: func(string) (string, error) { return "", errTest }},
+			expected: errTest,
+			record:   1,
+		},
+		{
+			name:     "canceled",
+			ctx:      canceled,
+			input:    "the end\n",
+			expected: context.Canceled,
+		},
+		{
+			name:     "invalid delimiter",
+			ctx:      context.Background(),
+			input:    "the end\n",
+			opts:     StreamOptions{Delimiter: "\n\n"},
+			expected: ErrInvalidDelimiter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			err := New(Options{}).Stream(tt.ctx, strings.NewReader(tt.input), &out, tt.opts)
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("Stream(%q) error = %v, want %v", tt.input, err, tt.expected)
+			}
+
+			var recordErr *RecordError
+			if errors.As(err, &recordErr) != (tt.record > 0) {
+				t.Errorf("Stream(%q) error = %#v, want RecordError: %v", tt.input, err, tt.record > 0)
+			} else if tt.record > 0 && recordErr.Record != tt.record {
+				t.Errorf("Stream(%q) failed at record %d, want %d", tt.input, recordErr.Record, tt.record)
+			}
+			if out.String() != tt.output {
+				t.Errorf("Stream(%q) wrote %q, want %q", tt.input, out.String(), tt.output)
+			}
+		})
+	}
+}
+
+var errTest = errors.New("test error")
+
+func TestStreamMaxLength(t *testing.T) {
+	input := "the end\n" + strings.Repeat("a", 10000) + "\r\nnever read\n"
+
+	var out strings.Builder
+	err := New(Options{MaxLength: 100}).Stream(context.Background(), strings.NewReader(input), &out, StreamOptions{})
+
+	var lengthErr *LengthError
+	if !errors.As(err, &lengthErr) {
+		t.Fatalf("Stream error = %#v, want *LengthError", err)
+	}
+	if lengthErr.Length != 10000 || lengthErr.Limit != 100 {
+		t.Errorf("LengthError = %+v, want Length 10000, Limit 100", lengthErr)
+	}
+	if out.String() != "The End\n" {
+		t.Errorf("Stream wrote %q, want %q", out.String(), "The End\n")
+	}
+
+	out.Reset()
+	long := strings.Repeat("word ", MaxInputLength/5) + "end\n"
+	titler := New(Options{MaxLength: -1})
+	if err := titler.Stream(context.Background(), strings.NewReader(long), &out, StreamOptions{}); err != nil {
+		t.Errorf("Stream with no limit returned unexpected error: %v", err)
+	}
+}
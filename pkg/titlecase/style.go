@@ -0,0 +1,164 @@
+package titlecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownStyle is returned by LookupStyle for names that match no
+// built-in Style.
+var ErrUnknownStyle = errors.New("unknown style")
+
+// Position describes where a word appears in a title.
+type Position struct {
+	// First reports whether the word starts the title or a subtitle.
+	First bool
+	// Last reports whether the word ends the title or a subtitle.
+	Last bool
+	// InCompound reports whether the word follows a hyphen inside a
+	// hyphenated compound.
+	InCompound bool
+	// Role is the part of speech the word plays in the title.
+	Role Role
+	// Prefix holds the preceding element of a hyphenated compound, in
+	// lowercase, when InCompound is set.
+	Prefix string
+}
+
+// Style decides which words of a title stay lowercase. Every other word
+// is capitalized.
+type Style interface {
+	// Name returns the identifier accepted by LookupStyle.
+	Name() string
+	// Lowercase reports whether word, given in lowercase, stays lowercase
+	// at pos.
+	Lowercase(word string, pos Position) bool
+	// Boundary reports whether punctuation, without surrounding whitespace,
+	// separates a title from a subtitle or another sentence, so that the
+	// words on either side of it are capitalized like first and last words.
+	Boundary(punctuation string) bool
+}
+
+var (
+	// Chicago follows the Chicago Manual of Style. It keeps SmallWords and
+	// prepositions of any length lowercase, but capitalizes words used as
+	// adverbs or phrasal-verb particles, as in "Set Up Your Account". In
+	// hyphenated compounds it lowercases the element after a prefix such as
+	// "anti" or a single letter, as in "Anti-inflammatory" and "E-mail", and
+	// capitalizes both halves of spelled-out numbers, as in "Twenty-First".
+	// Colons, semicolons, dashes and end punctuation start a new subtitle.
+	Chicago Style = chicagoStyle{}
+
+	// AP follows the Associated Press Stylebook, which lowercases articles,
+	// conjunctions and prepositions of three letters or fewer. Colons,
+	// semicolons, dashes and end punctuation start a new subtitle.
+	AP Style = &wordListStyle{
+		name:       "ap",
+		boundaries: ":;.?!—",
+		words: wordSet(
+			"a", "an", "the",
+			"and", "but", "for", "nor", "or", "so", "yet",
+			"as", "at", "by", "in", "of", "off", "on", "out", "per", "to", "up", "via",
+		),
+	}
+
+	// APA follows the American Psychological Association style, which
+	// lowercases short conjunctions, articles and short prepositions. Colons,
+	// dashes and end punctuation start a new subtitle.
+	APA Style = &wordListStyle{
+		name:       "apa",
+		boundaries: ":.?!—",
+		words: wordSet(
+			"a", "an", "the",
+			"and", "as", "but", "for", "if", "nor", "or", "so", "yet",
+			"at", "by", "in", "of", "off", "on", "per", "to", "up", "via",
+		),
+	}
+
+	// MLA follows the Modern Language Association style, which lowercases
+	// articles, coordinating conjunctions and prepositions of any length.
+	// Colons and end punctuation start a new subtitle.
+	MLA Style = &wordListStyle{
+		name:       "mla",
+		boundaries: ":.?!",
+		words: wordSet(
+			"a", "an", "the",
+			"and", "but", "for", "nor", "or", "so", "yet",
+			"about", "above", "across", "after", "against", "along", "among",
+			"around", "as", "at", "before", "behind", "below", "beneath",
+			"beside", "between", "beyond", "by", "despite", "down", "during",
+			"except", "from", "in", "inside", "into", "like", "near", "of",
+			"off", "on", "onto", "out", "outside", "over", "past", "per",
+			"since", "through", "throughout", "till", "to", "toward",
+			"towards", "under", "underneath", "until", "up", "upon", "via",
+			"with", "within", "without",
+		),
+	}
+)
+
+// Styles returns the built-in styles.
+func Styles() []Style {
+	return []Style{Chicago, AP, APA, MLA}
+}
+
+// LookupStyle returns the built-in Style with the given name, ignoring case.
+func LookupStyle(name string) (Style, error) {
+	for _, style := range Styles() {
+		if strings.EqualFold(style.Name(), name) {
+			return style, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownStyle, name)
+}
+
+type chicagoStyle struct{}
+
+func (chicagoStyle) Name() string {
+	return "chicago"
+}
+
+func (chicagoStyle) Lowercase(word string, pos Position) bool {
+	if pos.InCompound {
+		if isNumberWord(pos.Prefix) && isNumberWord(word) {
+			return false
+		}
+		if isPrefix(pos.Prefix) {
+			return true
+		}
+	}
+	if pos.First || pos.Last || pos.Role == RoleParticle {
+		return false
+	}
+	return SmallWords[word] || pos.Role == RolePreposition
+}
+
+func (chicagoStyle) Boundary(punctuation string) bool {
+	return hasBoundary(punctuation, ":;.?!—")
+}
+
+type wordListStyle struct {
+	name       string
+	words      map[string]bool
+	boundaries string
+}
+
+func (s *wordListStyle) Name() string {
+	return s.name
+}
+
+func (s *wordListStyle) Lowercase(word string, pos Position) bool {
+	return !pos.First && !pos.Last && pos.Role != RoleParticle && s.words[word]
+}
+
+func (s *wordListStyle) Boundary(punctuation string) bool {
+	return hasBoundary(punctuation, s.boundaries)
+}
+
+func wordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
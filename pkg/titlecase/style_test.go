@@ -0,0 +1,121 @@
+package titlecase
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStyles(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    Style
+		input    string
+		expected string
+	}{
+		{
+			name:     "chicago default",
+			style:    nil,
+			input:    "a guide to life with cats",
+			expected: "A Guide to Life with Cats",
+		},
+		{
+			name:     "chicago explicit",
+			style:    Chicago,
+			input:    "a guide to life with cats",
+			expected: "A Guide to Life with Cats",
+		},
+		{
+			name:     "ap capitalizes four letter prepositions",
+			style:    AP,
+			input:    "a guide to life with cats",
+			expected: "A Guide to Life With Cats",
+		},
+		{
+			name:     "ap lowercases short prepositions",
+			style:    AP,
+			input:    "the view from out of town",
+			expected: "The View From out of Town",
+		},
+		{
+			name:     "apa lowercases if",
+			style:    APA,
+			input:    "what happens if we wait",
+			expected: "What Happens if We Wait",
+		},
+		{
+			name:     "apa capitalizes with",
+			style:    APA,
+			input:    "living with anxiety",
+			expected: "Living With Anxiety",
+		},
+		{
+			name:     "mla lowercases long prepositions",
+			style:    MLA,
+			input:    "a walk through the forest between the hills",
+			expected: "A Walk through the Forest between the Hills",
+		},
+		{
+			name:     "mla capitalizes last preposition",
+			style:    MLA,
+			input:    "what dreams are made from",
+			expected: "What Dreams Are Made From",
+		},
+		{
+			name:     "style applies to compounds",
+			style:    MLA,
+			input:    "a walk-through of state-of-the-art tools",
+			expected: "A Walk-through of State-of-the-Art Tools",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := New(Options{Style: tt.style}).Title(tt.input)
+			if err != nil {
+				t.Errorf("Title(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("Title(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLookupStyle(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Style
+	}{
+		{name: "chicago", expected: Chicago},
+		{name: "AP", expected: AP},
+		{name: "apa", expected: APA},
+		{name: "Mla", expected: MLA},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style, err := LookupStyle(tt.name)
+			if err != nil {
+				t.Errorf("LookupStyle(%q) returned unexpected error: %v", tt.name, err)
+				return
+			}
+			if style != tt.expected {
+				t.Errorf("LookupStyle(%q) = %v, want %v", tt.name, style.Name(), tt.expected.Name())
+			}
+		})
+	}
+
+	if _, err := LookupStyle("harvard"); !errors.Is(err, ErrUnknownStyle) {
+		t.Errorf("LookupStyle(%q) error = %v, want %v", "harvard", err, ErrUnknownStyle)
+	}
+}
+
+func TestTitlerStyle(t *testing.T) {
+	if style := New(Options{}).Style(); style != Chicago {
+		t.Errorf("New(Options{}).Style() = %v, want Chicago", style.Name())
+	}
+	if style := New(Options{Style: MLA}).Style(); style != MLA {
+		t.Errorf("New(Options{Style: MLA}).Style() = %v, want MLA", style.Name())
+	}
+}
@@ -0,0 +1,614 @@
+// Package titlecase converts text into titles capitalized according to a
+// style guide such as the Chicago Manual of Style.
+//
+// ToTitleCase applies the default Chicago rules. Callers that need a
+// different Style or extra rules create a Titler with New.
+package titlecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxInputLength is the default maximum length of an input title in
+	// bytes, used when Options.MaxLength is zero.
+	MaxInputLength = 10000
+)
+
+var (
+	// ErrInputTooLong matches, with errors.Is, the *LengthError returned when
+	// the input is longer than the limit of the Titler.
+	ErrInputTooLong = errors.New("input text exceeds maximum length")
+	// ErrInvalidUnicode is returned when the input is not valid UTF-8.
+	ErrInvalidUnicode = errors.New("input contains invalid unicode")
+	// ErrEmptyInput is returned when the input contains no words.
+	ErrEmptyInput = errors.New("input cannot be empty")
+)
+
+// LengthError is returned when the input is longer than the limit set by
+// Options.MaxLength. It matches ErrInputTooLong with errors.Is.
+type LengthError struct {
+	// Length is the length of the input in bytes.
+	Length int
+	// Limit is the maximum length in bytes.
+	Limit int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("%v: %d bytes, limit is %d", ErrInputTooLong, e.Length, e.Limit)
+}
+
+// Is reports whether target is ErrInputTooLong.
+func (e *LengthError) Is(target error) bool {
+	return target == ErrInputTooLong
+}
+
+// SmallWords lists the words the Chicago style keeps lowercase unless they
+// start or end a title.
+var SmallWords = map[string]bool{
+	"a": true, "an": true, "and": true, "as": true, "at": true, "but": true,
+	"by": true, "for": true, "if": true, "in": true, "nor": true, "of": true,
+	"on": true, "or": true, "the": true, "to": true, "up": true, "yet": true,
+	"so": true, "with": true,
+}
+
+// Options configures a Titler. The zero value gives the same results as
+// ToTitleCase.
+type Options struct {
+	// Style selects the capitalization rules. A nil Style uses Chicago.
+	Style Style
+	// SmallWords lists extra words kept lowercase in addition to those of
+	// the Style.
+	SmallWords []string
+	// Dictionary holds words that are always written with a fixed spelling,
+	// whatever their position or input casing. A nil Dictionary uses
+	// DefaultDictionary; use NewDictionary() to disable the built-in
+	// spellings.
+	Dictionary *Dictionary
+	// Words lists words that are always written exactly as given, such as
+	// "GitHub" or "macOS". They are added to the Dictionary.
+	Words []string
+	// DisableMixedCase turns off the heuristic that keeps words with
+	// internal capitals, such as "useEffect" or "PowerShell", as written.
+	DisableMixedCase bool
+	// Acronyms lists extra acronyms, in addition to the built-in list, that
+	// stay uppercase even when the whole title is written in capitals.
+	Acronyms []string
+	// Escape is a marker that opts text out of conversion: text wrapped in
+	// it, as in "=iphone=" for Escape "=", is written unchanged and without
+	// the markers. An empty Escape disables the syntax.
+	Escape string
+	// MaxLength is the longest input, in bytes, that a Titler converts.
+	// Zero uses MaxInputLength and a negative value removes the limit.
+	MaxLength int
+}
+
+// Titler converts text to title case using a fixed set of Options. A Titler
+// is safe for concurrent use.
+type Titler struct {
+	style      Style
+	smallWords map[string]bool
+	dictionary *Dictionary
+	mixedCase  bool
+	acronyms   map[string]bool
+	escape     string
+	maxLength  int
+}
+
+var defaultTitler = New(Options{})
+
+// New returns a Titler configured by opts.
+func New(opts Options) *Titler {
+	t := &Titler{
+		style:      opts.Style,
+		smallWords: make(map[string]bool, len(opts.SmallWords)),
+		dictionary: opts.Dictionary,
+		mixedCase:  !opts.DisableMixedCase,
+		acronyms:   make(map[string]bool, len(builtinAcronyms)+len(opts.Acronyms)),
+		escape:     opts.Escape,
+		maxLength:  opts.MaxLength,
+	}
+	if t.style == nil {
+		t.style = Chicago
+	}
+	for _, word := range opts.SmallWords {
+		t.smallWords[strings.ToLower(word)] = true
+	}
+	if t.dictionary == nil {
+		t.dictionary = DefaultDictionary()
+	} else {
+		t.dictionary = NewDictionary(t.dictionary.Words()...)
+	}
+	t.dictionary.Add(opts.Words...)
+	for _, acronym := range builtinAcronyms {
+		t.acronyms[acronym] = true
+	}
+	for _, acronym := range opts.Acronyms {
+		t.acronyms[strings.ToUpper(acronym)] = true
+	}
+	if t.maxLength == 0 {
+		t.maxLength = MaxInputLength
+	}
+	return t
+}
+
+// Style returns the Style used by t.
+func (t *Titler) Style() Style {
+	return t.style
+}
+
+// MaxLength returns the longest input, in bytes, that t converts, or a
+// negative value if the length is unlimited.
+func (t *Titler) MaxLength() int {
+	return t.maxLength
+}
+
+// Token is a run of text produced by Tokenize. Whitespace tokens have neither
+// IsWord nor IsPunctuation set.
+type Token struct {
+	Text          string
+	IsWord        bool
+	IsPunctuation bool
+	// Protected is set on words such as URLs, email addresses, file paths
+	// and dotted names like "node.js", whose casing is never changed.
+	Protected bool
+}
+
+// Tokenize splits text into word, punctuation and whitespace tokens. URLs,
+// email addresses, file paths, dotted names, backtick code spans, template
+// placeholders such as "{{.Name}}" and printf verbs such as "%s" are kept
+// whole as protected words.
+// Concatenating the Text of every token gives back the original text.
+func Tokenize(text string) []Token {
+	return tokenize(text, "")
+}
+
+func tokenize(text string, escape string) []Token {
+	var tokens []Token
+	runes := []rune(text)
+	var currentToken strings.Builder
+	var isInWord bool
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if i == 0 || unicode.IsSpace(runes[i-1]) {
+			if start, end := protectedSpan(runes, i); end > start {
+				if start > i {
+					tokens = append(tokens, Token{
+						Text:          string(runes[i:start]),
+						IsPunctuation: true,
+					})
+				}
+				tokens = append(tokens, Token{
+					Text:      string(runes[start:end]),
+					IsWord:    true,
+					Protected: true,
+				})
+				i = end - 1
+				continue
+			}
+		}
+
+		if end := inlineSpan(runes, i, escape); end > i {
+			if currentToken.Len() > 0 {
+				tokens = append(tokens, Token{
+					Text:          currentToken.String(),
+					IsWord:        isInWord,
+					IsPunctuation: !isInWord,
+				})
+				currentToken.Reset()
+			}
+			tokens = append(tokens, Token{
+				Text:      string(runes[i:end]),
+				IsWord:    true,
+				Protected: true,
+			})
+			isInWord = false
+			i = end - 1
+			continue
+		}
+
+		if unicode.IsSpace(r) {
+			if currentToken.Len() > 0 {
+				tokens = append(tokens, Token{
+					Text:          currentToken.String(),
+					IsWord:        isInWord,
+					IsPunctuation: !isInWord,
+				})
+				currentToken.Reset()
+			}
+			tokens = append(tokens, Token{
+				Text:          string(r),
+				IsWord:        false,
+				IsPunctuation: false,
+			})
+			isInWord = false
+		} else if unicode.IsLetter(r) || unicode.IsDigit(r) || isValidWordCharacter(r, runes, i) {
+			if !isInWord && currentToken.Len() > 0 {
+				tokens = append(tokens, Token{
+					Text:          currentToken.String(),
+					IsWord:        false,
+					IsPunctuation: true,
+				})
+				currentToken.Reset()
+			}
+			currentToken.WriteRune(r)
+			isInWord = true
+		} else {
+			if isInWord && currentToken.Len() > 0 {
+				tokens = append(tokens, Token{
+					Text:          currentToken.String(),
+					IsWord:        true,
+					IsPunctuation: false,
+				})
+				currentToken.Reset()
+			}
+			currentToken.WriteRune(r)
+			isInWord = false
+		}
+	}
+
+	if currentToken.Len() > 0 {
+		tokens = append(tokens, Token{
+			Text:          currentToken.String(),
+			IsWord:        isInWord,
+			IsPunctuation: !isInWord,
+		})
+	}
+
+	// A standalone run of hyphens is a dash, not a word.
+	for i, token := range tokens {
+		if token.IsWord && !token.Protected && strings.Trim(token.Text, "-") == "" {
+			tokens[i].IsWord = false
+			tokens[i].IsPunctuation = true
+		}
+	}
+
+	return tokens
+}
+
+func isValidWordCharacter(r rune, runes []rune, index int) bool {
+	if r == '-' {
+		return true
+	}
+
+	if isApostrophe(r) {
+		return index > 0 && index < len(runes)-1 &&
+			isLetterOrDigit(runes[index-1]) &&
+			unicode.IsLetter(runes[index+1])
+	}
+
+	return false
+}
+
+func (t *Titler) processTokens(tokens []Token) (string, error) {
+	titled, err := t.titleTokens(tokens)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(titled, ""), nil
+}
+
+func (t *Titler) titleTokens(tokens []Token) ([]string, error) {
+	decisions, err := t.decide(tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	titled := make([]string, len(decisions))
+	for i, decision := range decisions {
+		titled[i] = decision.Output
+	}
+
+	return titled, nil
+}
+
+func (t *Titler) decide(tokens []Token) ([]Decision, error) {
+	original := tokens
+	if isShouting(tokens) {
+		tokens = t.normalizeShouting(tokens)
+	}
+
+	decisions := make([]Decision, len(tokens))
+	roles := tagRoles(tokens)
+	wordCount := 0
+	wordIndices := make([]int, len(tokens))
+
+	for i, token := range tokens {
+		if token.IsWord {
+			wordIndices[i] = wordCount
+			wordCount++
+		}
+	}
+
+	for i, token := range tokens {
+		if !token.IsWord {
+			decisions[i] = Decision{Token: token, Output: token.Text, Rule: RuleUnchanged}
+			continue
+		}
+
+		wordIndex := wordIndices[i]
+		firstWord := wordIndex == 0
+		lastWord := wordIndex == wordCount-1
+		pos := Position{
+			First: firstWord || t.shouldCapitalizeAfterPunctuation(tokens, i),
+			Last:  lastWord || t.precedesBoundary(tokens, i),
+			Role:  roles[i],
+		}
+
+		if token.Protected {
+			decisions[i] = Decision{Token: token, Output: t.unescape(token.Text), Rule: RuleProtected, Position: pos}
+			continue
+		}
+
+		decision, err := t.decideWord(token.Text, pos)
+		if err != nil {
+			return nil, err
+		}
+		if decision.Rule == RuleFirstWord && !firstWord {
+			decision.Rule = RuleAfterPunctuation
+		}
+		if decision.Rule == RuleLastWord && !lastWord {
+			decision.Rule = RuleBeforePunctuation
+		}
+		decision.Token = original[i]
+		decisions[i] = decision
+	}
+
+	return decisions, nil
+}
+
+func (t *Titler) shouldCapitalizeAfterPunctuation(tokens []Token, currentIndex int) bool {
+	if t.followsBoundary(tokens, currentIndex) {
+		return true
+	}
+
+	for i := currentIndex - 1; i >= 0; i-- {
+		token := tokens[i]
+		if token.IsWord {
+			return false
+		}
+		if !token.IsPunctuation {
+			continue
+		}
+
+		punctuation := strings.TrimSpace(token.Text)
+		startsGroup := i == 0 || (!tokens[i-1].IsWord && !tokens[i-1].IsPunctuation)
+		if i == currentIndex-1 && startsGroup && endsWithOpeningMark(punctuation) {
+			return true
+		}
+		if i == currentIndex-2 && startsGroup && isSpacedOpeningMark(punctuation) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func endsWithOpeningMark(punctuation string) bool {
+	r, _ := utf8.DecodeLastRuneInString(punctuation)
+	return r == '(' || strings.ContainsRune(quoteMarks, r)
+}
+
+// ToTitleCase converts text to title case using the default Options.
+func ToTitleCase(text string) (string, error) {
+	return defaultTitler.Title(text)
+}
+
+// Title converts text to title case. Protected text, such as URLs, code spans,
+// placeholders and escaped words, is left unchanged. It returns
+// ErrEmptyInput, a *LengthError matching ErrInputTooLong or ErrInvalidUnicode
+// when text cannot be converted.
+func (t *Titler) Title(text string) (string, error) {
+	tokens, err := t.tokenizeInput(text)
+	if err != nil {
+		return "", err
+	}
+
+	return t.processTokens(tokens)
+}
+
+func (t *Titler) tokenizeInput(text string) ([]Token, error) {
+	if text == "" {
+		return nil, ErrEmptyInput
+	}
+
+	if t.maxLength > 0 && len(text) > t.maxLength {
+		return nil, &LengthError{Length: len(text), Limit: t.maxLength}
+	}
+
+	if !utf8.ValidString(text) {
+		return nil, ErrInvalidUnicode
+	}
+
+	tokens := tokenize(text, t.escape)
+	if len(tokens) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	hasWords := false
+	for _, token := range tokens {
+		if token.IsWord {
+			hasWords = true
+			break
+		}
+	}
+
+	if !hasWords {
+		return nil, ErrEmptyInput
+	}
+
+	return tokens, nil
+}
+
+func (t *Titler) decideWord(word string, pos Position) (Decision, error) {
+	if word == "" {
+		return wordDecision(word, word, RuleUnchanged, pos), nil
+	}
+
+	if !utf8.ValidString(word) {
+		return Decision{}, ErrInvalidUnicode
+	}
+
+	if exact, ok := t.lookupWord(word); ok {
+		return wordDecision(word, exact, RuleDictionary, pos), nil
+	}
+
+	if strings.Contains(word, "-") {
+		return t.decideHyphenatedWord(word, pos)
+	}
+
+	return t.decideSingleWord(word, pos)
+}
+
+func (t *Titler) decideHyphenatedWord(word string, pos Position) (Decision, error) {
+	parts := strings.Split(word, "-")
+	titleParts := make([]string, len(parts))
+	decisions := make([]Decision, 0, len(parts))
+	isEdge := pos.First || pos.Last
+
+	for i, part := range parts {
+		if part == "" {
+			titleParts[i] = part
+			continue
+		}
+
+		partPos := Position{
+			First:      isEdge && i == 0,
+			Last:       isEdge && i == len(parts)-1,
+			InCompound: i > 0,
+		}
+		// A capital after a lowercase prefix, as in "anti-American", marks a
+		// proper noun or adjective, which keeps its capital.
+		if i > 0 && !isProperAfterPrefix(parts[i-1], part) {
+			partPos.Prefix = strings.ToLower(parts[i-1])
+		}
+		decision, err := t.decideSingleWord(part, partPos)
+		if err != nil {
+			return Decision{}, err
+		}
+		titleParts[i] = decision.Output
+		decisions = append(decisions, decision)
+	}
+
+	decision := wordDecision(word, strings.Join(titleParts, "-"), RuleHyphenated, pos)
+	decision.Parts = decisions
+	return decision, nil
+}
+
+func (t *Titler) decideSingleWord(word string, pos Position) (Decision, error) {
+	if word == "" {
+		return wordDecision(word, word, RuleUnchanged, pos), nil
+	}
+
+	if !utf8.ValidString(word) {
+		return Decision{}, ErrInvalidUnicode
+	}
+
+	if exact, ok := t.lookupWord(word); ok {
+		return wordDecision(word, exact, RuleDictionary, pos), nil
+	}
+
+	if number, ok := titleNumber(word); ok {
+		return wordDecision(word, number, RuleNumber, pos), nil
+	}
+
+	if rule, ok := t.preservedCasing(word); ok {
+		if rule == RuleAcronym {
+			return wordDecision(word, acronymCasing(word), rule, pos), nil
+		}
+		return wordDecision(word, word, rule, pos), nil
+	}
+
+	lowerWord := strings.ToLower(word)
+
+	if t.isLowercase(lowerWord, pos) {
+		return wordDecision(word, lowerWord, t.lowercaseRule(lowerWord, pos), pos), nil
+	}
+
+	capitalized, err := capitalizeFirst(lowerWord)
+	if err != nil {
+		return Decision{}, err
+	}
+	return wordDecision(word, capitalized, t.capitalizeRule(lowerWord, pos), pos), nil
+}
+
+func isProperAfterPrefix(prefix, part string) bool {
+	r, _ := utf8.DecodeRuneInString(part)
+	return unicode.IsUpper(r) && prefix == strings.ToLower(prefix) && isPrefix(prefix)
+}
+
+func capitalizeFirst(word string) (string, error) {
+	if word == "" {
+		return word, nil
+	}
+
+	if !utf8.ValidString(word) {
+		return "", ErrInvalidUnicode
+	}
+
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return word, nil
+	}
+
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes), nil
+}
+
+func (t *Titler) shouldPreserveOriginalCasing(word string) bool {
+	_, ok := t.preservedCasing(word)
+	return ok
+}
+
+func (t *Titler) preservedCasing(word string) (Rule, bool) {
+	if len(word) < 2 {
+		return RuleUnchanged, false
+	}
+
+	runes := []rune(word)
+	allUpper := true
+	hasLetter := false
+	letterCount := 0
+
+	for _, r := range runes {
+		if unicode.IsLetter(r) {
+			hasLetter = true
+			letterCount++
+			if !unicode.IsUpper(r) {
+				allUpper = false
+			}
+		}
+	}
+
+	if !hasLetter {
+		return RuleUnchanged, false
+	}
+
+	if allUpper && letterCount >= 2 && letterCount <= 6 {
+		return RuleAcronym, true
+	}
+
+	if t.isAcronym(word) {
+		return RuleAcronym, true
+	}
+
+	if t.mixedCase && isMixedCase(word) {
+		return RuleMixedCase, true
+	}
+
+	return RuleUnchanged, false
+}
+
+func (t *Titler) isLowercase(word string, pos Position) bool {
+	if t.smallWords[word] && !pos.First && !pos.Last && pos.Role != RoleParticle {
+		return true
+	}
+	return t.style.Lowercase(word, pos)
+}
@@ -1,6 +1,7 @@
 package titlecase
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -69,7 +70,7 @@ func TestToTitleCase(t *testing.T) {
 		{
 			name:     "hyphenated at beginning",
 			input:    "co-founder of the company",
-			expected: "Co-Founder of the Company",
+			expected: "Co-founder of the Company",
 		},
 		{
 			name:     "hyphenated at end",
@@ -99,7 +100,7 @@ func TestToTitleCase(t *testing.T) {
 		{
 			name:     "mixed abbreviations",
 			input:    "FBI investigation about USA",
-			expected: "FBI Investigation About USA",
+			expected: "FBI Investigation about USA",
 		},
 		{
 			name:     "tech abbreviations",
@@ -109,7 +110,7 @@ func TestToTitleCase(t *testing.T) {
 		{
 			name:     "special case iOS",
 			input:    "iOS app development",
-			expected: "Ios App Development",
+			expected: "iOS App Development",
 		},
 		{
 			name:     "abbreviations with small words",
@@ -164,7 +165,7 @@ func TestToTitleCase(t *testing.T) {
 		{
 			name:     "multiple punctuation types",
 			input:    `text, with; various: punctuation!`,
-			expected: `Text, with; Various: Punctuation!`,
+			expected: `Text, With; Various: Punctuation!`,
 		},
 		{
 			name:     "abbreviations in quotes",
@@ -218,7 +219,7 @@ func TestToTitleCaseErrors(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result, err := ToTitleCase(tt.input)
-			if err != tt.expectedErr {
+			if !errors.Is(err, tt.expectedErr) {
 				t.Errorf("ToTitleCase(%q) error = %v, want %v", tt.input, err, tt.expectedErr)
 			}
 			if err != nil && result != "" {
@@ -228,7 +229,7 @@ func TestToTitleCaseErrors(t *testing.T) {
 	}
 }
 
-func TestTitleWord(t *testing.T) {
+func TestDecideWord(t *testing.T) {
 	tests := []struct {
 		name          string
 		word          string
@@ -263,19 +264,20 @@ func TestTitleWord(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := titleWord(tt.word, tt.isFirstOrLast)
+			decision, err := defaultTitler.decideWord(tt.word, Position{First: tt.isFirstOrLast})
+			result := decision.Output
 			if err != nil {
-				t.Errorf("titleWord(%q, %t) returned unexpected error: %v", tt.word, tt.isFirstOrLast, err)
+				t.Errorf("decideWord(%q, %t) returned unexpected error: %v", tt.word, tt.isFirstOrLast, err)
 				return
 			}
 			if result != tt.expected {
-				t.Errorf("titleWord(%q, %t) = %q, want %q", tt.word, tt.isFirstOrLast, result, tt.expected)
+				t.Errorf("decideWord(%q, %t) = %q, want %q", tt.word, tt.isFirstOrLast, result, tt.expected)
 			}
 		})
 	}
 }
 
-func TestTitleWordErrors(t *testing.T) {
+func TestDecideWordErrors(t *testing.T) {
 	tests := []struct {
 		name        string
 		word        string
@@ -290,12 +292,13 @@ func TestTitleWordErrors(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := titleWord(tt.word, false)
-			if err != tt.expectedErr {
-				t.Errorf("titleWord(%q) error = %v, want %v", tt.word, err, tt.expectedErr)
+			decision, err := defaultTitler.decideWord(tt.word, Position{})
+			result := decision.Output
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("decideWord(%q) error = %v, want %v", tt.word, err, tt.expectedErr)
 			}
 			if err != nil && result != "" {
-				t.Errorf("titleWord(%q) returned non-empty result on error: %q", tt.word, result)
+				t.Errorf("decideWord(%q) returned non-empty result on error: %q", tt.word, result)
 			}
 		})
 	}
@@ -364,7 +367,7 @@ func TestCapitalizeFirstErrors(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result, err := capitalizeFirst(tt.input)
-			if err != tt.expectedErr {
+			if !errors.Is(err, tt.expectedErr) {
 				t.Errorf("capitalizeFirst(%q) error = %v, want %v", tt.input, err, tt.expectedErr)
 			}
 			if err != nil && result != "" {
@@ -374,7 +377,7 @@ func TestCapitalizeFirstErrors(t *testing.T) {
 	}
 }
 
-func TestTitleHyphenatedWord(t *testing.T) {
+func TestDecideHyphenatedWord(t *testing.T) {
 	tests := []struct {
 		name          string
 		word          string
@@ -397,7 +400,7 @@ func TestTitleHyphenatedWord(t *testing.T) {
 			name:          "hyphenated at beginning",
 			word:          "co-founder",
 			isFirstOrLast: true,
-			expected:      "Co-Founder",
+			expected:      "Co-founder",
 		},
 		{
 			name:          "hyphenated at end",
@@ -427,13 +430,14 @@ func TestTitleHyphenatedWord(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := titleHyphenatedWord(tt.word, tt.isFirstOrLast)
+			decision, err := defaultTitler.decideHyphenatedWord(tt.word, Position{First: tt.isFirstOrLast})
+			result := decision.Output
 			if err != nil {
-				t.Errorf("titleHyphenatedWord(%q, %t) returned unexpected error: %v", tt.word, tt.isFirstOrLast, err)
+				t.Errorf("decideHyphenatedWord(%q, %t) returned unexpected error: %v", tt.word, tt.isFirstOrLast, err)
 				return
 			}
 			if result != tt.expected {
-				t.Errorf("titleHyphenatedWord(%q, %t) = %q, want %q", tt.word, tt.isFirstOrLast, result, tt.expected)
+				t.Errorf("decideHyphenatedWord(%q, %t) = %q, want %q", tt.word, tt.isFirstOrLast, result, tt.expected)
 			}
 		})
 	}
@@ -458,11 +462,46 @@ func TestShouldPreserveOriginalCasing(t *testing.T) {
 		{
 			name:     "mixed case abbreviation",
 			word:     "iOS",
-			expected: false,
+			expected: true,
 		},
 		{
 			name:     "mixed case word with many caps",
 			word:     "XMLHttpRequest",
+			expected: true,
+		},
+		{
+			name:     "camel case identifier",
+			word:     "getElementById",
+			expected: true,
+		},
+		{
+			name:     "pascal case name",
+			word:     "McDonald",
+			expected: true,
+		},
+		{
+			name:     "mixed case possessive",
+			word:     "PowerShell's",
+			expected: true,
+		},
+		{
+			name:     "random casing",
+			word:     "tHe",
+			expected: false,
+		},
+		{
+			name:     "alternating casing",
+			word:     "QuIcK",
+			expected: false,
+		},
+		{
+			name:     "trailing capital",
+			word:     "FoX",
+			expected: false,
+		},
+		{
+			name:     "shift key held too long",
+			word:     "HEllo",
 			expected: false,
 		},
 		{
@@ -494,7 +533,7 @@ func TestShouldPreserveOriginalCasing(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := shouldPreserveOriginalCasing(tt.word)
+			result := defaultTitler.shouldPreserveOriginalCasing(tt.word)
 			if result != tt.expected {
 				t.Errorf("shouldPreserveOriginalCasing(%q) = %t, want %t", tt.word, result, tt.expected)
 			}
@@ -502,7 +541,7 @@ func TestShouldPreserveOriginalCasing(t *testing.T) {
 	}
 }
 
-func TestPreserveOrCapitalize(t *testing.T) {
+func TestDecideSingleWord(t *testing.T) {
 	tests := []struct {
 		name          string
 		word          string
@@ -516,10 +555,22 @@ func TestPreserveOrCapitalize(t *testing.T) {
 			expected:      "USA",
 		},
 		{
-			name:          "preserve mixed case",
+			name:          "preserve dictionary word",
 			word:          "iOS",
 			isFirstOrLast: false,
-			expected:      "Ios",
+			expected:      "iOS",
+		},
+		{
+			name:          "preserve mixed case",
+			word:          "fooBar",
+			isFirstOrLast: false,
+			expected:      "fooBar",
+		},
+		{
+			name:          "lowercase random case",
+			word:          "fOo",
+			isFirstOrLast: false,
+			expected:      "Foo",
 		},
 		{
 			name:          "regular word middle",
@@ -543,13 +594,219 @@ func TestPreserveOrCapitalize(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := preserveOrCapitalize(tt.word, tt.isFirstOrLast)
+			decision, err := defaultTitler.decideSingleWord(tt.word, Position{First: tt.isFirstOrLast})
+			result := decision.Output
+			if err != nil {
+				t.Errorf("decideSingleWord(%q, %t) returned unexpected error: %v", tt.word, tt.isFirstOrLast, err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("decideSingleWord(%q, %t) = %q, want %q", tt.word, tt.isFirstOrLast, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTitlerSmallWords(t *testing.T) {
+	tests := []struct {
+		name       string
+		smallWords []string
+		input      string
+		expected   string
+	}{
+		{
+			name:     "default options",
+			input:    "cats vs dogs",
+			expected: "Cats Vs Dogs",
+		},
+		{
+			name:       "extra small word",
+			smallWords: []string{"vs"},
+			input:      "cats vs dogs",
+			expected:   "Cats vs Dogs",
+		},
+		{
+			name:       "extra small words are case insensitive",
+			smallWords: []string{"VS", "Versus"},
+			input:      "cats vs dogs versus birds",
+			expected:   "Cats vs Dogs versus Birds",
+		},
+		{
+			name:       "extra small word at end",
+			smallWords: []string{"from"},
+			input:      "where we come from",
+			expected:   "Where We Come From",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			titler := New(Options{SmallWords: tt.smallWords})
+			result, err := titler.Title(tt.input)
+			if err != nil {
+				t.Errorf("Title(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("Title(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	inputs := []string{
+		"the quick brown fox",
+		`it's a "self-driving" car`,
+		"text, with; various: punctuation!",
+		"  leading and trailing  ",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			var b strings.Builder
+			for _, token := range Tokenize(input) {
+				b.WriteString(token.Text)
+			}
+			if b.String() != input {
+				t.Errorf("Tokenize(%q) joined = %q, want original text", input, b.String())
+			}
+		})
+	}
+}
+
+func TestTitlerWords(t *testing.T) {
+	titler := New(Options{Words: []string{"GitHub", "macOS", "gRPC", "e-mail"}})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "exact casing kept",
+			input:    "using github on macos",
+			expected: "Using GitHub on macOS",
+		},
+		{
+			name:     "first word",
+			input:    "grpc for beginners",
+			expected: "gRPC for Beginners",
+		},
+		{
+			name:     "any input casing",
+			input:    "GITHUB ACTIONS",
+			expected: "GitHub Actions",
+		},
+		{
+			name:     "hyphenated word",
+			input:    "send an E-Mail",
+			expected: "Send an e-mail",
+		},
+		{
+			name:     "hyphenated part",
+			input:    "a github-hosted runner",
+			expected: "A GitHub-Hosted Runner",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := titler.Title(tt.input)
 			if err != nil {
-				t.Errorf("preserveOrCapitalize(%q, %t) returned unexpected error: %v", tt.word, tt.isFirstOrLast, err)
+				t.Errorf("Title(%q) returned unexpected error: %v", tt.input, err)
 				return
 			}
 			if result != tt.expected {
-				t.Errorf("preserveOrCapitalize(%q, %t) = %q, want %q", tt.word, tt.isFirstOrLast, result, tt.expected)
+				t.Errorf("Title(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMixedCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		input    string
+		expected string
+	}{
+		{
+			name:     "identifier kept",
+			input:    "using useEffect in react",
+			expected: "Using useEffect in React",
+		},
+		{
+			name:     "names kept",
+			input:    "why McDonald loves PowerShell",
+			expected: "Why McDonald Loves PowerShell",
+		},
+		{
+			name:     "identifier at start",
+			input:    "getElementById explained",
+			expected: "getElementById Explained",
+		},
+		{
+			name:     "random casing normalized",
+			input:    "tHe QuIcK bRoWn FoX",
+			expected: "The Quick Brown Fox",
+		},
+		{
+			name:     "heuristic disabled",
+			opts:     Options{DisableMixedCase: true},
+			input:    "using useEffect in react",
+			expected: "Using Useeffect in React",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := New(tt.opts).Title(tt.input)
+			if err != nil {
+				t.Errorf("Title(%q) returned unexpected error: %v", tt.input, err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("Title(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMaxLength(t *testing.T) {
+	long := strings.Repeat("word ", MaxInputLength/5) + "end"
+
+	tests := []struct {
+		name      string
+		maxLength int
+		input     string
+		limit     int
+	}{
+		{name: "default limit", input: long, limit: MaxInputLength},
+		{name: "lower limit", maxLength: 10, input: "the lord of the rings", limit: 10},
+		{name: "within limit", maxLength: 10, input: "the end"},
+		{name: "unlimited", maxLength: -1, input: long},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(Options{MaxLength: tt.maxLength}).Title(tt.input)
+			if tt.limit == 0 {
+				if err != nil {
+					t.Errorf("Title returned unexpected error: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrInputTooLong) {
+				t.Fatalf("Title error = %v, want ErrInputTooLong", err)
+			}
+			var lengthErr *LengthError
+			if !errors.As(err, &lengthErr) {
+				t.Fatalf("Title error = %#v, want *LengthError", err)
+			}
+			if lengthErr.Length != len(tt.input) || lengthErr.Limit != tt.limit {
+				t.Errorf("LengthError = %+v, want Length %d, Limit %d", lengthErr, len(tt.input), tt.limit)
 			}
 		})
 	}